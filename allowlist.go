@@ -0,0 +1,280 @@
+package licensechecker
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ledongthuc/licensechecker/expression"
+	"github.com/pkg/errors"
+)
+
+// maximumAlternatives is the highest number of choice sets that are kept for a sub-expression, the smallest ones are
+// kept, so a long AND of ORs doesn't grow exponentially
+const maximumAlternatives = 64
+
+var (
+	ErrorAllowedTerm = errors.New("Allowed entry must be a license, an exception or a license with an exception")
+)
+
+// versionedIDPattern breaks license ID into family, version and scope, e.g. "GPL-2.0-only" is "GPL", "2.0" and "-only"
+var versionedIDPattern = regexp.MustCompile(`^(.+?)-(\d+(?:\.\d+)*)(-only|-or-later)?$`)
+
+// Compliance is result of evaluating SPDX expression with an allow-list
+type Compliance struct {
+	// Satisfiable is true if expression can be complied with by allowed licenses only
+	Satisfiable bool
+	// Chosen are the fewest terms, licenses or licenses with exceptions, that comply with expression. A term with "+"
+	// or "-or-later" is chosen as its allowed version, e.g. "GPL-3.0-only" for "GPL-2.0-or-later".
+	Chosen []string
+	// Unsatisfied are terms that aren't allowed and prevent compliance, they're empty if expression is satisfiable
+	Unsatisfied []string
+}
+
+// allowList keeps allowed terms and exceptions by their lower case canonical form
+type allowList struct {
+	terms      map[string]struct{}
+	exceptions map[string]struct{}
+	// licenses are current licenses ordered by ID, they're the later versions of "+" and "-or-later" terms
+	licenses []LicenseInfo
+}
+
+// evaluation is alternative choice sets that satisfy an expression, or its unsatisfied terms if there isn't any
+type evaluation struct {
+	alternatives [][]string
+	unsatisfied  []string
+}
+
+// EvaluateAllowList checks whether SPDX expression can be complied with by allowed licenses, and which choices to make,
+// e.g. "MIT OR GPL-3.0-only" with only MIT allowed is satisfiable by choosing MIT. Allowed entries are license IDs,
+// exception IDs or licenses with exceptions. A license with an exception is allowed if the whole term is allowed, or
+// both the license and the exception are allowed. A license with "+" or "-or-later" is allowed if any of its versions
+// from the license on is allowed. IDs are case-insensitive, and deprecated IDs are the same as their successors.
+func EvaluateAllowList(text string, allowed []string) (Compliance, error) {
+	e, err := canonicalExpression(text)
+	if err != nil {
+		return Compliance{}, errors.Wrap(err, "Error when parse expression '"+text+"'")
+	}
+	infos, err := AllInfo()
+	if err != nil {
+		return Compliance{}, err
+	}
+
+	list := allowList{terms: map[string]struct{}{}, exceptions: map[string]struct{}{}}
+	exceptionIDs := map[string]struct{}{}
+	for _, info := range infos {
+		switch {
+		case info.Kind == KindException:
+			exceptionIDs[info.LicenseID] = struct{}{}
+		case !info.IsDeprecated:
+			list.licenses = append(list.licenses, info)
+		}
+	}
+	for _, entry := range allowed {
+		term, err := canonicalExpression(entry)
+		if err != nil {
+			return Compliance{}, errors.Wrap(err, "Error when parse allowed entry '"+entry+"'")
+		}
+		switch node := term.(type) {
+		case expression.License:
+			if _, ok := exceptionIDs[node.ID]; ok {
+				list.exceptions[strings.ToLower(node.ID)] = struct{}{}
+				continue
+			}
+		case expression.With:
+		default:
+			return Compliance{}, errors.Wrap(ErrorAllowedTerm, "Error when parse allowed entry '"+entry+"'")
+		}
+		list.terms[strings.ToLower(term.String())] = struct{}{}
+	}
+
+	result := list.evaluate(e)
+	if len(result.alternatives) == 0 {
+		return Compliance{Chosen: []string{}, Unsatisfied: result.unsatisfied}, nil
+	}
+	chosen := result.alternatives[0]
+	for _, alternative := range result.alternatives[1:] {
+		if len(alternative) < len(chosen) {
+			chosen = alternative
+		}
+	}
+	return Compliance{Satisfiable: true, Chosen: chosen, Unsatisfied: []string{}}, nil
+}
+
+// canonicalExpression parses SPDX expression with canonical case of IDs, and current IDs instead of deprecated ones
+func canonicalExpression(text string) (expression.Expression, error) {
+	e, err := expression.Parse(text)
+	if err != nil {
+		return nil, err
+	}
+	e, _, err = expression.Validate(e)
+	if err != nil {
+		return nil, err
+	}
+	// A successor can't be parsed if it's a WITH expression in place of a license of WITH, then IDs are kept
+	if resolved, err := expression.Parse(resolveDeprecatedIDs(e.String())); err == nil {
+		e = resolved
+	}
+	return e, nil
+}
+
+// evaluate finds choice sets of expression. A term has a choice set for each of its allowed versions, AND combines
+// choice sets of both sides, and OR takes choice sets of either side. Only the smallest choice sets are kept.
+func (l allowList) evaluate(e expression.Expression) evaluation {
+	switch node := e.(type) {
+	case expression.License:
+		return l.evaluateTerm(node, "")
+	case expression.With:
+		return l.evaluateTerm(node.License, node.Exception)
+	case expression.And:
+		left, right := l.evaluate(node.Left), l.evaluate(node.Right)
+		if len(left.alternatives) == 0 || len(right.alternatives) == 0 {
+			return evaluation{unsatisfied: union(left.unsatisfied, right.unsatisfied)}
+		}
+		alternatives := [][]string{}
+		for _, a := range left.alternatives {
+			for _, b := range right.alternatives {
+				alternatives = append(alternatives, union(a, b))
+			}
+		}
+		return evaluation{alternatives: minimalSets(alternatives)}
+	case expression.Or:
+		left, right := l.evaluate(node.Left), l.evaluate(node.Right)
+		if len(left.alternatives) == 0 && len(right.alternatives) == 0 {
+			return evaluation{unsatisfied: union(left.unsatisfied, right.unsatisfied)}
+		}
+		return evaluation{alternatives: minimalSets(append(left.alternatives, right.alternatives...))}
+	}
+	return evaluation{}
+}
+
+// evaluateTerm finds allowed versions of license with optional exception
+func (l allowList) evaluateTerm(license expression.License, exception string) evaluation {
+	alternatives := [][]string{}
+	for _, licenseID := range l.versions(license) {
+		if !l.allows(licenseID, exception) {
+			continue
+		}
+		if exception != "" {
+			licenseID += " WITH " + exception
+		}
+		alternatives = append(alternatives, []string{licenseID})
+	}
+	if len(alternatives) == 0 {
+		term := license.String()
+		if exception != "" {
+			term += " WITH " + exception
+		}
+		return evaluation{unsatisfied: []string{term}}
+	}
+	return evaluation{alternatives: alternatives}
+}
+
+// versions are license IDs that comply with license: the license itself, then current licenses of its family from its
+// version on if it has "+" or "-or-later", e.g. "GPL-2.0-or-later" has "GPL-2.0-only", "GPL-3.0-only", ...
+func (l allowList) versions(license expression.License) []string {
+	licenseIDs := []string{license.String()}
+	match := versionedIDPattern.FindStringSubmatch(license.ID)
+	if match == nil || !(license.OrLater || match[3] == "-or-later") {
+		return licenseIDs
+	}
+	later := []string{}
+	for _, info := range l.licenses {
+		m := versionedIDPattern.FindStringSubmatch(info.LicenseID)
+		if m != nil && m[1] == match[1] && compareVersions(m[2], match[2]) >= 0 && info.LicenseID != license.String() {
+			later = append(later, info.LicenseID)
+		}
+	}
+	sort.SliceStable(later, func(i, j int) bool {
+		return compareVersions(versionedIDPattern.FindStringSubmatch(later[i])[2], versionedIDPattern.FindStringSubmatch(later[j])[2]) < 0
+	})
+	return append(licenseIDs, later...)
+}
+
+// allows checks whether license with optional exception is allowed
+func (l allowList) allows(licenseID, exception string) bool {
+	_, licenseAllowed := l.terms[strings.ToLower(licenseID)]
+	if exception == "" {
+		return licenseAllowed
+	}
+	if _, ok := l.terms[strings.ToLower(licenseID+" WITH "+exception)]; ok {
+		return true
+	}
+	_, exceptionAllowed := l.exceptions[strings.ToLower(exception)]
+	return licenseAllowed && exceptionAllowed
+}
+
+// compareVersions compares dotted versions by their numbers, e.g. "2.0" is lower than "10"
+func compareVersions(a, b string) int {
+	partsA, partsB := strings.Split(a, "."), strings.Split(b, ".")
+	for index := 0; index < len(partsA) || index < len(partsB); index++ {
+		var numberA, numberB int
+		if index < len(partsA) {
+			numberA, _ = strconv.Atoi(partsA[index])
+		}
+		if index < len(partsB) {
+			numberB, _ = strconv.Atoi(partsB[index])
+		}
+		if numberA != numberB {
+			if numberA < numberB {
+				return -1
+			}
+			return 1
+		}
+	}
+	return 0
+}
+
+// union appends items of b that a doesn't have
+func union(a, b []string) []string {
+	result := append([]string{}, a...)
+	for _, item := range b {
+		if !containsAll(result, []string{item}) {
+			result = append(result, item)
+		}
+	}
+	return result
+}
+
+// containsAll checks whether set has all items
+func containsAll(set, items []string) bool {
+	for _, item := range items {
+		found := false
+		for _, s := range set {
+			if s == item {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// minimalSets removes choice sets that contain another set, the first one of equal sets is kept. At most
+// maximumAlternatives of the smallest sets are kept.
+func minimalSets(sets [][]string) [][]string {
+	result := [][]string{}
+	for i, set := range sets {
+		dominated := false
+		for j, other := range sets {
+			if i != j && containsAll(set, other) && (len(other) < len(set) || j < i) {
+				dominated = true
+				break
+			}
+		}
+		if !dominated {
+			result = append(result, set)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return len(result[i]) < len(result[j])
+	})
+	if len(result) > maximumAlternatives {
+		result = result[:maximumAlternatives]
+	}
+	return result
+}
@@ -0,0 +1,163 @@
+package licensechecker
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/ledongthuc/licensechecker/expression"
+	"github.com/pkg/errors"
+)
+
+func TestEvaluateAllowList(t *testing.T) {
+	tests := []struct {
+		name        string
+		expression  string
+		allowed     []string
+		satisfiable bool
+		chosen      []string
+		unsatisfied []string
+	}{
+		{
+			name:        "Choose allowed license",
+			expression:  "MIT OR GPL-3.0-only",
+			allowed:     []string{"MIT"},
+			satisfiable: true,
+			chosen:      []string{"MIT"},
+			unsatisfied: []string{},
+		},
+		{
+			name:        "Conjunction with license that isn't allowed",
+			expression:  "MIT AND GPL-3.0-only",
+			allowed:     []string{"MIT"},
+			chosen:      []string{},
+			unsatisfied: []string{"GPL-3.0-only"},
+		},
+		{
+			name:        "Nothing is allowed",
+			expression:  "mit or isc",
+			allowed:     []string{},
+			chosen:      []string{},
+			unsatisfied: []string{"MIT", "ISC"},
+		},
+		{
+			name:        "Minimal set",
+			expression:  "(MIT OR Apache-2.0) AND (Apache-2.0 OR BSD-3-Clause)",
+			allowed:     []string{"MIT", "Apache-2.0", "BSD-3-Clause"},
+			satisfiable: true,
+			chosen:      []string{"Apache-2.0"},
+			unsatisfied: []string{},
+		},
+		{
+			name:        "Later version of plus",
+			expression:  "GPL-2.0+",
+			allowed:     []string{"GPL-3.0-only"},
+			satisfiable: true,
+			chosen:      []string{"GPL-3.0-only"},
+			unsatisfied: []string{},
+		},
+		{
+			name:        "Or later of other family",
+			expression:  "Apache-1.1+",
+			allowed:     []string{"apache-2.0"},
+			satisfiable: true,
+			chosen:      []string{"Apache-2.0"},
+			unsatisfied: []string{},
+		},
+		{
+			name:        "Earlier version of or later",
+			expression:  "GPL-3.0-or-later",
+			allowed:     []string{"GPL-2.0-only", "LGPL-3.0-only"},
+			chosen:      []string{},
+			unsatisfied: []string{"GPL-3.0-or-later"},
+		},
+		{
+			name:        "Only version",
+			expression:  "GPL-2.0",
+			allowed:     []string{"GPL-2.0-or-later"},
+			chosen:      []string{},
+			unsatisfied: []string{"GPL-2.0-only"},
+		},
+		{
+			name:        "License without its exception",
+			expression:  "GPL-2.0-only WITH Classpath-exception-2.0",
+			allowed:     []string{"GPL-2.0-only"},
+			chosen:      []string{},
+			unsatisfied: []string{"GPL-2.0-only WITH Classpath-exception-2.0"},
+		},
+		{
+			name:        "License and exception",
+			expression:  "GPL-2.0-or-later WITH Classpath-exception-2.0",
+			allowed:     []string{"GPL-3.0-only", "Classpath-exception-2.0"},
+			satisfiable: true,
+			chosen:      []string{"GPL-3.0-only WITH Classpath-exception-2.0"},
+			unsatisfied: []string{},
+		},
+		{
+			name:        "Deprecated license with exception",
+			expression:  "GPL-2.0-with-classpath-exception",
+			allowed:     []string{"gpl-2.0-only with classpath-exception-2.0"},
+			satisfiable: true,
+			chosen:      []string{"GPL-2.0-only WITH Classpath-exception-2.0"},
+			unsatisfied: []string{},
+		},
+		{
+			name:        "User defined license",
+			expression:  "LicenseRef-Internal OR MIT",
+			allowed:     []string{"LicenseRef-Internal"},
+			satisfiable: true,
+			chosen:      []string{"LicenseRef-Internal"},
+			unsatisfied: []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := EvaluateAllowList(tt.expression, tt.allowed)
+			if err != nil {
+				t.Errorf("EvaluateAllowList() error = %v", err)
+				return
+			}
+			want := Compliance{Satisfiable: tt.satisfiable, Chosen: tt.chosen, Unsatisfied: tt.unsatisfied}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("EvaluateAllowList() = %+v, want %+v", got, want)
+			}
+		})
+	}
+}
+
+func TestEvaluateAllowList_errors(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		allowed    []string
+		wantErr    error
+	}{
+		{name: "Invalid expression", expression: "MIT OR", wantErr: expression.ErrorInvalidExpression},
+		{name: "Invalid allowed entry", expression: "MIT", allowed: []string{"MIT ("}, wantErr: expression.ErrorInvalidExpression},
+		{name: "Compound allowed entry", expression: "MIT", allowed: []string{"MIT OR ISC"}, wantErr: ErrorAllowedTerm},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := EvaluateAllowList(tt.expression, tt.allowed); errors.Cause(err) != tt.wantErr {
+				t.Errorf("EvaluateAllowList() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func Test_compareVersions(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{a: "2.0", b: "2.0", want: 0},
+		{a: "2.0", b: "10", want: -1},
+		{a: "2.1", b: "2", want: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+"-"+tt.b, func(t *testing.T) {
+			if got := compareVersions(tt.a, tt.b); got != tt.want {
+				t.Errorf("compareVersions() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -2,6 +2,7 @@ package licensechecker
 
 import (
 	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/ledongthuc/licensechecker/internal/data"
@@ -23,12 +24,48 @@ type License struct {
 	LicenseContent
 }
 
+// Kind tells whether an SPDX entry is a license or a license exception
+type Kind int
+
+const (
+	KindUnknown Kind = iota
+	KindLicense
+	KindException
+)
+
+func (k Kind) String() string {
+	switch k {
+	case KindLicense:
+		return "license"
+	case KindException:
+		return "exception"
+	}
+	return "unknown"
+}
+
 // LicenseInfo contains meta data of license. It doesn't contain license content, just info.
 type LicenseInfo struct {
 	LicenseID    string
 	Name         string
 	References   []string
 	IsDeprecated bool
+	Kind         Kind
+	// IsOsiApproved and IsFsfLibre are statuses of license by OSI and FSF, they're always false for exceptions
+	IsOsiApproved bool
+	IsFsfLibre    bool
+	// Reference is SPDX page of license, relative to https://spdx.org/licenses/, e.g. "./MIT.html"
+	Reference string
+	// DetailsURL is SPDX JSON details of license, e.g. "http://spdx.org/licenses/MIT.json"
+	DetailsURL      string
+	ReferenceNumber string
+}
+
+// clone copies info, so its references can be changed without changing the catalog
+func (info LicenseInfo) clone() LicenseInfo {
+	if info.References != nil {
+		info.References = append([]string{}, info.References...)
+	}
+	return info
 }
 
 // LicenseContent contains license's content
@@ -38,9 +75,10 @@ type LicenseContent struct {
 	RawContent []byte
 }
 
-// All will loads and returns all license that have with their content and me meta data.
-func All() ([]License, error) {
-	info, err := AllInfo()
+// All will loads and returns all license that have with their content and me meta data. Kinds choose licenses,
+// exceptions or both of them if kinds are empty.
+func All(kinds ...Kind) ([]License, error) {
+	info, err := AllInfo(kinds...)
 	if err != nil {
 		return []License{}, err
 	}
@@ -61,34 +99,17 @@ func All() ([]License, error) {
 }
 
 // AllInfo will get all license's information that doesn't contains license's content, just light weight meta data.
-func AllInfo() ([]LicenseInfo, error) {
-	// Load licenses
-	standardLicenses, err := loadStandardLicenses()
-	if err != nil {
-		return []LicenseInfo{}, err
-	}
-	exceptionLicenses, err := loadExceptionLicenses()
+// Kinds choose licenses, exceptions or both of them if kinds are empty. Entries of each kind are ordered by ID.
+func AllInfo(kinds ...Kind) ([]LicenseInfo, error) {
+	namespaces, err := loadNamespaces(kinds)
 	if err != nil {
 		return []LicenseInfo{}, err
 	}
 
-	// Merge license
-	merging := make(map[string]LicenseInfo)
-	err = convertStandardLicenses(merging, standardLicenses)
-	if err != nil {
-		return []LicenseInfo{}, err
+	result := []LicenseInfo{}
+	for _, n := range namespaces {
+		result = append(result, n.List()...)
 	}
-
-	err = convertExceptionLicenses(merging, exceptionLicenses)
-	if err != nil {
-		return []LicenseInfo{}, err
-	}
-
-	result := make([]LicenseInfo, 0, len(merging))
-	for _, m := range merging {
-		result = append(result, m)
-	}
-
 	return result, nil
 }
 
@@ -104,9 +125,10 @@ func GetByInfo(info LicenseInfo) (License, error) {
 	}, nil
 }
 
-// SearchByName loads full license content base on LicenseInfo
-func SearchByName(partOfName string, caseSensitive bool) ([]License, error) {
-	info, err := AllInfo()
+// SearchByName loads full license content base on LicenseInfo. Current licenses go before deprecated ones, then they're ordered by ID.
+// Kinds choose licenses, exceptions or both of them if kinds are empty.
+func SearchByName(partOfName string, caseSensitive bool, kinds ...Kind) ([]License, error) {
+	info, err := AllInfo(kinds...)
 	if err != nil {
 		return []License{}, err
 	}
@@ -131,6 +153,12 @@ func SearchByName(partOfName string, caseSensitive bool) ([]License, error) {
 			LicenseContent: content,
 		})
 	}
+	sort.SliceStable(result, func(i, j int) bool {
+		if result[i].IsDeprecated != result[j].IsDeprecated {
+			return !result[i].IsDeprecated
+		}
+		return result[i].LicenseInfo.LicenseID < result[j].LicenseInfo.LicenseID
+	})
 	return result, nil
 }
 
@@ -176,10 +204,16 @@ func convertStandardLicenses(container map[string]LicenseInfo, ls license) error
 	}
 	for _, l := range ls.Licenses {
 		container[l.LicenseID] = LicenseInfo{
-			LicenseID:    l.LicenseID,
-			Name:         l.Name,
-			References:   l.SeeAlso,
-			IsDeprecated: l.IsDeprecatedLicenseID,
+			LicenseID:       l.LicenseID,
+			Name:            l.Name,
+			References:      l.SeeAlso,
+			IsDeprecated:    l.IsDeprecatedLicenseID,
+			Kind:            KindLicense,
+			IsOsiApproved:   l.IsOsiApproved,
+			IsFsfLibre:      l.IsFsfLibre,
+			Reference:       l.Reference,
+			DetailsURL:      l.DetailsURL,
+			ReferenceNumber: l.ReferenceNumber,
 		}
 	}
 	return nil
@@ -192,10 +226,14 @@ func convertExceptionLicenses(container map[string]LicenseInfo, ls exception) er
 	}
 	for _, l := range ls.Exceptions {
 		container[l.LicenseExceptionID] = LicenseInfo{
-			LicenseID:    l.LicenseExceptionID,
-			Name:         l.Name,
-			References:   l.SeeAlso,
-			IsDeprecated: l.IsDeprecatedLicenseID,
+			LicenseID:       l.LicenseExceptionID,
+			Name:            l.Name,
+			References:      l.SeeAlso,
+			IsDeprecated:    l.IsDeprecatedLicenseID,
+			Kind:            KindException,
+			Reference:       l.Reference,
+			DetailsURL:      l.DetailsURL,
+			ReferenceNumber: l.ReferenceNumber,
 		}
 	}
 	return nil
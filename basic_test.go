@@ -192,7 +192,12 @@ func TestSearchByName(t *testing.T) {
 		References: []string{
 			"http://landley.net/toybox/license.html",
 		},
-		IsDeprecated: false,
+		IsDeprecated:    false,
+		Kind:            KindLicense,
+		IsOsiApproved:   true,
+		Reference:       "./0BSD.html",
+		DetailsURL:      "http://spdx.org/licenses/0BSD.json",
+		ReferenceNumber: "319",
 	})
 	AGPL10, _ := GetByInfo(LicenseInfo{
 		LicenseID: "AGPL-1.0",
@@ -200,7 +205,12 @@ func TestSearchByName(t *testing.T) {
 		References: []string{
 			"http://www.affero.org/oagpl.html",
 		},
-		IsDeprecated: true,
+		IsDeprecated:    true,
+		Kind:            KindLicense,
+		IsFsfLibre:      true,
+		Reference:       "./AGPL-1.0.html",
+		DetailsURL:      "http://spdx.org/licenses/AGPL-1.0.json",
+		ReferenceNumber: "335",
 	})
 	AGPL10Only, _ := GetByInfo(LicenseInfo{
 		LicenseID: "AGPL-1.0-only",
@@ -208,7 +218,11 @@ func TestSearchByName(t *testing.T) {
 		References: []string{
 			"http://www.affero.org/oagpl.html",
 		},
-		IsDeprecated: false,
+		IsDeprecated:    false,
+		Kind:            KindLicense,
+		Reference:       "./AGPL-1.0-only.html",
+		DetailsURL:      "http://spdx.org/licenses/AGPL-1.0-only.json",
+		ReferenceNumber: "384",
 	})
 	AGPL10OrLater, _ := GetByInfo(LicenseInfo{
 		LicenseID: "AGPL-1.0-or-later",
@@ -216,7 +230,11 @@ func TestSearchByName(t *testing.T) {
 		References: []string{
 			"http://www.affero.org/oagpl.html",
 		},
-		IsDeprecated: false,
+		IsDeprecated:    false,
+		Kind:            KindLicense,
+		Reference:       "./AGPL-1.0-or-later.html",
+		DetailsURL:      "http://spdx.org/licenses/AGPL-1.0-or-later.json",
+		ReferenceNumber: "332",
 	})
 	AGPL30, _ := GetByInfo(LicenseInfo{
 		LicenseID: "AGPL-3.0",
@@ -225,7 +243,13 @@ func TestSearchByName(t *testing.T) {
 			"https://www.gnu.org/licenses/agpl.txt",
 			"https://opensource.org/licenses/AGPL-3.0",
 		},
-		IsDeprecated: true,
+		IsDeprecated:    true,
+		Kind:            KindLicense,
+		IsOsiApproved:   true,
+		IsFsfLibre:      true,
+		Reference:       "./AGPL-3.0.html",
+		DetailsURL:      "http://spdx.org/licenses/AGPL-3.0.json",
+		ReferenceNumber: "229",
 	})
 	AGPL30Only, _ := GetByInfo(LicenseInfo{
 		LicenseID: "AGPL-3.0-only",
@@ -234,7 +258,13 @@ func TestSearchByName(t *testing.T) {
 			"https://www.gnu.org/licenses/agpl.txt",
 			"https://opensource.org/licenses/AGPL-3.0",
 		},
-		IsDeprecated: false,
+		IsDeprecated:    false,
+		Kind:            KindLicense,
+		IsOsiApproved:   true,
+		IsFsfLibre:      true,
+		Reference:       "./AGPL-3.0-only.html",
+		DetailsURL:      "http://spdx.org/licenses/AGPL-3.0-only.json",
+		ReferenceNumber: "95",
 	})
 	AGPL30OrLater, _ := GetByInfo(LicenseInfo{
 		LicenseID: "AGPL-3.0-or-later",
@@ -243,7 +273,13 @@ func TestSearchByName(t *testing.T) {
 			"https://www.gnu.org/licenses/agpl.txt",
 			"https://opensource.org/licenses/AGPL-3.0",
 		},
-		IsDeprecated: false,
+		IsDeprecated:    false,
+		Kind:            KindLicense,
+		IsOsiApproved:   true,
+		IsFsfLibre:      true,
+		Reference:       "./AGPL-3.0-or-later.html",
+		DetailsURL:      "http://spdx.org/licenses/AGPL-3.0-or-later.json",
+		ReferenceNumber: "155",
 	})
 
 	type args struct {
@@ -522,3 +558,21 @@ func TestAll(t *testing.T) {
 		}
 	}
 }
+
+func TestKind_String(t *testing.T) {
+	tests := []struct {
+		kind Kind
+		want string
+	}{
+		{kind: KindLicense, want: "license"},
+		{kind: KindException, want: "exception"},
+		{kind: KindUnknown, want: "unknown"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := tt.kind.String(); got != tt.want {
+				t.Errorf("Kind.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
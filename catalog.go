@@ -0,0 +1,107 @@
+package licensechecker
+
+import (
+	"sort"
+	"sync"
+)
+
+// Catalog is SPDX license list with its licenses and exceptions. It's loaded once and isn't changed after that, so
+// it's safe for concurrent use.
+type Catalog struct {
+	licenses    Namespace
+	exceptions  Namespace
+	version     string
+	releaseDate string
+}
+
+var (
+	loadedCatalogOnce sync.Once
+	loadedCatalog     *Catalog
+	loadedCatalogErr  error
+)
+
+// LoadCatalog loads SPDX license list from assets once, then re-uses it for next calls
+func LoadCatalog() (*Catalog, error) {
+	loadedCatalogOnce.Do(func() {
+		loadedCatalog, loadedCatalogErr = newCatalog()
+	})
+	return loadedCatalog, loadedCatalogErr
+}
+
+// newCatalog parses licenses and exceptions of SPDX license list
+func newCatalog() (*Catalog, error) {
+	standardLicenses, err := loadStandardLicenses()
+	if err != nil {
+		return nil, err
+	}
+	licenses := make(map[string]LicenseInfo, len(standardLicenses.Licenses))
+	if err := convertStandardLicenses(licenses, standardLicenses); err != nil {
+		return nil, err
+	}
+
+	exceptionLicenses, err := loadExceptionLicenses()
+	if err != nil {
+		return nil, err
+	}
+	exceptions := make(map[string]LicenseInfo, len(exceptionLicenses.Exceptions))
+	if err := convertExceptionLicenses(exceptions, exceptionLicenses); err != nil {
+		return nil, err
+	}
+
+	return &Catalog{
+		licenses:    newNamespace(KindLicense, licenses),
+		exceptions:  newNamespace(KindException, exceptions),
+		version:     standardLicenses.LicenseListVersion,
+		releaseDate: standardLicenses.ReleaseDate,
+	}, nil
+}
+
+// Get looks up a license or an exception by its ID case-insensitively, e.g. "mit" is "MIT". A license goes before an
+// exception with the same ID.
+func (c *Catalog) Get(licenseID string) (LicenseInfo, bool) {
+	if info, ok := c.licenses.Get(licenseID); ok {
+		return info, true
+	}
+	return c.exceptions.Get(licenseID)
+}
+
+// List returns entries of kinds ordered by ID, or all licenses and exceptions if kinds are empty. A license goes
+// before an exception with the same ID.
+func (c *Catalog) List(kinds ...Kind) []LicenseInfo {
+	if len(kinds) == 0 {
+		kinds = []Kind{KindLicense, KindException}
+	}
+	result := []LicenseInfo{}
+	for _, kind := range kinds {
+		switch kind {
+		case KindLicense:
+			result = append(result, c.licenses.List()...)
+		case KindException:
+			result = append(result, c.exceptions.List()...)
+		}
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return result[i].LicenseID < result[j].LicenseID
+	})
+	return result
+}
+
+// Licenses returns namespace of standard licenses
+func (c *Catalog) Licenses() Namespace {
+	return c.licenses
+}
+
+// Exceptions returns namespace of license exceptions
+func (c *Catalog) Exceptions() Namespace {
+	return c.exceptions
+}
+
+// Version is version of SPDX license list, e.g. "3.6"
+func (c *Catalog) Version() string {
+	return c.version
+}
+
+// ReleaseDate is release date of SPDX license list, e.g. "2019-07-10"
+func (c *Catalog) ReleaseDate() string {
+	return c.releaseDate
+}
@@ -0,0 +1,100 @@
+package licensechecker
+
+import (
+	"sort"
+	"sync"
+	"testing"
+)
+
+func TestLoadCatalog(t *testing.T) {
+	c, err := LoadCatalog()
+	if err != nil {
+		t.Errorf("LoadCatalog() error = %v", err)
+		return
+	}
+	if again, _ := LoadCatalog(); again != c {
+		t.Errorf("LoadCatalog() loads the catalog again")
+	}
+
+	standardLicenses, err := loadStandardLicenses()
+	if err != nil {
+		t.Errorf("loadStandardLicenses() error = %v", err)
+		return
+	}
+	if c.Version() != standardLicenses.LicenseListVersion || c.ReleaseDate() != standardLicenses.ReleaseDate {
+		t.Errorf("Version(), ReleaseDate() = %v, %v, want %v, %v", c.Version(), c.ReleaseDate(), standardLicenses.LicenseListVersion, standardLicenses.ReleaseDate)
+	}
+}
+
+func TestCatalog_Get(t *testing.T) {
+	c, err := LoadCatalog()
+	if err != nil {
+		t.Errorf("LoadCatalog() error = %v", err)
+		return
+	}
+	tests := []struct {
+		licenseID string
+		want      string
+		kind      Kind
+		found     bool
+	}{
+		{licenseID: "MIT", want: "MIT", kind: KindLicense, found: true},
+		{licenseID: "apache-2.0", want: "Apache-2.0", kind: KindLicense, found: true},
+		{licenseID: "CLASSPATH-EXCEPTION-2.0", want: "Classpath-exception-2.0", kind: KindException, found: true},
+		{licenseID: "Unknown-1.0", found: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.licenseID, func(t *testing.T) {
+			got, found := c.Get(tt.licenseID)
+			if found != tt.found || got.LicenseID != tt.want || got.Kind != tt.kind {
+				t.Errorf("Get() = %v of %v, %v, want %v of %v, %v", got.LicenseID, got.Kind, found, tt.want, tt.kind, tt.found)
+			}
+		})
+	}
+
+	got, _ := c.Get("Apache-2.0")
+	got.References[0] = "changed"
+	if again, _ := c.Get("Apache-2.0"); again.References[0] == "changed" {
+		t.Errorf("Get() shares references with the catalog")
+	}
+}
+
+func TestCatalog_List(t *testing.T) {
+	c, err := LoadCatalog()
+	if err != nil {
+		t.Errorf("LoadCatalog() error = %v", err)
+		return
+	}
+	all := c.List()
+	if len(all) != len(c.Licenses().List())+len(c.Exceptions().List()) {
+		t.Errorf("List() has %d entries, want all licenses and exceptions", len(all))
+	}
+	if !sort.SliceIsSorted(all, func(i, j int) bool { return all[i].LicenseID < all[j].LicenseID }) {
+		t.Errorf("List() isn't ordered by ID")
+	}
+	for _, info := range c.List(KindException) {
+		if info.Kind != KindException {
+			t.Errorf("List() of exceptions has %v of %v", info.LicenseID, info.Kind)
+		}
+	}
+}
+
+func TestCatalog_concurrent(t *testing.T) {
+	var wait sync.WaitGroup
+	for index := 0; index < 8; index++ {
+		wait.Add(1)
+		go func() {
+			defer wait.Done()
+			c, err := LoadCatalog()
+			if err != nil {
+				t.Errorf("LoadCatalog() error = %v", err)
+				return
+			}
+			if _, found := c.Get("mit"); !found {
+				t.Errorf("Get() doesn't find MIT")
+			}
+			c.List()
+		}()
+	}
+	wait.Wait()
+}
@@ -1,19 +1,34 @@
 package commands
 
 import (
+	"context"
 	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"strings"
 
+	"github.com/ledongthuc/licensechecker"
+	"github.com/parnurzeal/gorequest"
+	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 )
 
 var (
-	paramDetectPath string
-	paramDetectURL  string
+	paramDetectPath    string
+	paramDetectURL     string
+	paramDetectExplain bool
+	paramDetectJSON    bool
+	paramDetectMaxSize int64
 )
 
 func init() {
 	detectCmd.Flags().StringVarP(&paramDetectPath, "path", "p", "", "Path of license file")
 	detectCmd.Flags().StringVarP(&paramDetectURL, "url", "u", "", "Url path to license file")
+	detectCmd.Flags().BoolVarP(&paramDetectExplain, "explain", "e", false, "Explain why the license is detected")
+	detectCmd.Flags().BoolVarP(&paramDetectJSON, "json", "j", false, "Print detected license and explanation as JSON")
+	detectCmd.Flags().Int64Var(&paramDetectMaxSize, "max-size", licensechecker.DefaultMaxSize, "Maximum number of bytes to read")
 	rootCmd.AddCommand(detectCmd)
 }
 
@@ -32,12 +47,76 @@ Support 3 ways to use:
 We also support pipe input :
 	echo "MIT License Copyright (c) Permission is hereby granted..." | glicense detect
 
+Explain the detection with candidates, their key phrases, distinguishing clauses and normalization steps:
+	glicense detect --explain -p /path/to/license/file
+	glicense detect --json -p /path/to/license/file
+
 Note:
  - Direct license content will have a higher priority to get.
  - If the detect command is set both arguments of a local path (-p) and URL (-u), local path will be used.
 
 `,
 	Run: func(cmd *cobra.Command, args []string) {
-		fmt.Println("Detect function - under construction")
+		reader, err := openDetectContent(args)
+		if err != nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		defer reader.Close()
+
+		explain := paramDetectExplain || paramDetectJSON
+		result, err := licensechecker.DetectReader(context.Background(), reader, licensechecker.ReaderOptions{
+			DetectOptions: licensechecker.DetectOptions{Explain: explain},
+			MaxSize:       paramDetectMaxSize,
+		})
+		if err != nil && result.Explanation == nil {
+			fmt.Println(err)
+			os.Exit(1)
+		}
+		if paramDetectJSON {
+			output, err := result.JSON()
+			if err != nil {
+				fmt.Println(err)
+				os.Exit(1)
+			}
+			fmt.Println(string(output))
+			return
+		}
+		if err != nil {
+			fmt.Println(err)
+		} else {
+			fmt.Printf("%s (%s), confidence %.3f\n", result.Name, result.LicenseID, result.Confidence)
+		}
+		if explain {
+			fmt.Printf("\n%s", result.Explanation.Text())
+		}
 	},
 }
+
+// openDetectContent opens license content from argument, then local path, URL and standard input
+func openDetectContent(args []string) (io.ReadCloser, error) {
+	if len(args) > 0 {
+		return ioutil.NopCloser(strings.NewReader(args[0])), nil
+	}
+	if paramDetectPath != "" {
+		file, err := os.Open(paramDetectPath)
+		if err != nil {
+			return nil, errors.Wrap(err, "Error when open license file '"+paramDetectPath+"'")
+		}
+		return file, nil
+	}
+	if paramDetectURL != "" {
+		resp, body, errs := gorequest.New().Get(paramDetectURL).End()
+		if len(errs) > 0 {
+			return nil, errors.Wrap(errs[0], "Error when get license from '"+paramDetectURL+"'")
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, errors.New("Error when get license from '" + paramDetectURL + "': " + resp.Status)
+		}
+		return ioutil.NopCloser(strings.NewReader(body)), nil
+	}
+	if stat, err := os.Stdin.Stat(); err == nil && stat.Mode()&os.ModeCharDevice == 0 {
+		return ioutil.NopCloser(os.Stdin), nil
+	}
+	return nil, errors.New("License content, path (-p) or URL (-u) is required")
+}
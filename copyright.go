@@ -0,0 +1,210 @@
+package licensechecker
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ledongthuc/licensechecker/normalize"
+)
+
+var (
+	commentMarkerPattern     = regexp.MustCompile(`^\s*(?:/\*+|\*+/|\*+|//+|#+|<!--|-->|--|;+|%+|'|rem\s|dnl\s)?\s*`)
+	copyrightWordPattern     = regexp.MustCompile(`(?i)\bcopyright\b|©|\(c\)`)
+	allRightsReservedPattern = regexp.MustCompile(`(?i)\ball\s+rights\s+reserved\.?`)
+	emailPattern             = regexp.MustCompile(`<?([\w.+-]+@[\w-]+(?:\.[\w-]+)+)>?`)
+	urlPattern               = regexp.MustCompile(`<?https?://[^\s>]+>?`)
+	yearRangePattern         = regexp.MustCompile(`(?i)\b((?:19|20)\d{2})(?:\s*[-–—]\s*((?:19|20)\d{2}|\d{2}|present|now)\b)?`)
+	holderSeparatorPattern   = regexp.MustCompile(`\s*[,;]\s*`)
+	holderConjunctionPattern = regexp.MustCompile(`(?i)(?:^|\s+)and\s+`)
+	companySuffixPattern     = regexp.MustCompile(`(?i)^(?:inc|ltd|llc|corp|co|gmbh|ag|plc|limited|incorporated|corporation)\b\.?`)
+	abbreviationPattern      = regexp.MustCompile(`(?i)^(?:inc|ltd|corp|co)\.$`)
+	holderTrimPattern        = regexp.MustCompile(`(?i)^(?:by\s+|[\s,.:;-]+)+|(?:\s+(?:and|&))?[\s,:;-]*$`)
+	// sentencePattern matches a line that starts prose instead of holders, e.g. "Permission is hereby granted" or
+	// "THE SOFTWARE IS PROVIDED"
+	sentencePattern = regexp.MustCompile(`^(?:\p{Lu}\p{Ll}*(?:\s+\p{Ll}+){2}|\p{Lu}{2,}(?:\s+\p{Lu}{2,}){3})\b`)
+)
+
+// YearRange is a range of years in copyright statement. From and To are the same for single year, To is 0 if it's "present".
+type YearRange struct {
+	From int
+	To   int
+}
+
+// Copyright is a copyright statement found in text
+type Copyright struct {
+	Holders           []string
+	Years             []YearRange
+	Emails            []string
+	AllRightsReserved bool
+	// Line is original text of the statement, it can be several lines
+	Line string
+	// Start and End are byte range [Start, End) of the statement in text
+	Start int
+	End   int
+}
+
+// copyrightLine is a line of text with comment markers stripped
+type copyrightLine struct {
+	text   string
+	indent int
+	start  int
+	end    int
+}
+
+// ExtractCopyrights finds all copyright statements in license text or source file. A statement starts with "Copyright", "©" or "(c)",
+// and continues on next lines when its holder list does, e.g. the line ends with a comma or next line is indented further.
+func ExtractCopyrights(content []byte) []Copyright {
+	lines := splitCopyrightLines(string(content))
+	result := []Copyright{}
+	for index := 0; index < len(lines); index++ {
+		line := lines[index]
+		if !normalize.IsCopyrightStatement(line.text) {
+			continue
+		}
+
+		statement := []copyrightLine{line}
+		for index+1 < len(lines) && isCopyrightContinuation(statement[len(statement)-1], line, lines[index+1]) {
+			index++
+			statement = append(statement, lines[index])
+		}
+		result = append(result, parseCopyright(content, statement))
+	}
+	return result
+}
+
+// splitCopyrightLines breaks text into lines with their offsets, and strips comment markers
+func splitCopyrightLines(content string) []copyrightLine {
+	lines := []copyrightLine{}
+	start := 0
+	for start <= len(content) {
+		end := strings.IndexByte(content[start:], '\n')
+		if end < 0 {
+			end = len(content)
+		} else {
+			end += start
+		}
+		raw := strings.TrimRight(content[start:end], "\r")
+		marker := commentMarkerPattern.FindString(raw)
+		lines = append(lines, copyrightLine{
+			text:   strings.TrimSpace(raw[len(marker):]),
+			indent: len(marker),
+			start:  start,
+			end:    start + len(raw),
+		})
+		start = end + 1
+	}
+	return lines
+}
+
+// isCopyrightContinuation checks whether next line continues holder list of previous line. A line that starts a
+// sentence doesn't continue it, even if previous line ends with a comma.
+func isCopyrightContinuation(previous, first, next copyrightLine) bool {
+	if next.text == "" || normalize.IsCopyrightStatement(next.text) || sentencePattern.MatchString(next.text) {
+		return false
+	}
+	lower := strings.ToLower(previous.text)
+	if strings.HasSuffix(lower, ",") || strings.HasSuffix(lower, " and") || strings.HasSuffix(lower, "&") {
+		return true
+	}
+	return next.indent >= first.indent+4 && len(next.text) < 80
+}
+
+// parseCopyright extracts years, emails and holders from lines of a statement
+func parseCopyright(content []byte, lines []copyrightLine) Copyright {
+	texts := make([]string, 0, len(lines))
+	for _, line := range lines {
+		texts = append(texts, line.text)
+	}
+	text := strings.Join(texts, ", ")
+
+	result := Copyright{
+		Line:   string(content[lines[0].start:lines[len(lines)-1].end]),
+		Start:  lines[0].start,
+		End:    lines[len(lines)-1].end,
+		Years:  []YearRange{},
+		Emails: []string{},
+	}
+
+	if allRightsReservedPattern.MatchString(text) {
+		result.AllRightsReserved = true
+		text = allRightsReservedPattern.ReplaceAllString(text, " ")
+	}
+	for _, match := range emailPattern.FindAllStringSubmatch(text, -1) {
+		result.Emails = append(result.Emails, match[1])
+	}
+	text = emailPattern.ReplaceAllString(text, " ")
+	text = urlPattern.ReplaceAllString(text, " ")
+	text = copyrightWordPattern.ReplaceAllString(text, " ")
+	for _, match := range yearRangePattern.FindAllStringSubmatch(text, -1) {
+		result.Years = append(result.Years, parseYearRange(match[1], match[2]))
+	}
+	text = yearRangePattern.ReplaceAllString(text, " ")
+
+	result.Holders = splitHolders(text)
+	return result
+}
+
+// parseYearRange converts years of a range, 2 digits end year is in the same century with start year
+func parseYearRange(from, to string) YearRange {
+	result := YearRange{}
+	result.From, _ = strconv.Atoi(from)
+	switch {
+	case to == "":
+		result.To = result.From
+	case strings.EqualFold(to, "present") || strings.EqualFold(to, "now"):
+		result.To = 0
+	case len(to) == 2:
+		shortYear, _ := strconv.Atoi(to)
+		result.To = result.From/100*100 + shortYear
+	default:
+		result.To, _ = strconv.Atoi(to)
+	}
+	return result
+}
+
+// splitHolders breaks the rest of statement into holders by commas and semicolons. Company suffixes like ", Inc." stay
+// with their holder. "and" only separates the last 2 holders of a list, e.g. "Alice, Bob and Carol", so names like
+// "Johnson and Johnson" are kept. "&" doesn't separate holders, it's a part of names like "AT&T" and "Procter & Gamble".
+func splitHolders(text string) []string {
+	holders := []string{}
+	position := 0
+	for _, loc := range holderSeparatorPattern.FindAllStringIndex(text, -1) {
+		if strings.Contains(text[loc[0]:loc[1]], ",") && companySuffixPattern.MatchString(text[loc[1]:]) {
+			continue
+		}
+		holders = appendHolder(holders, text[position:loc[0]])
+		position = loc[1]
+	}
+	last := text[position:]
+	if len(holders) > 0 {
+		if locs := holderConjunctionPattern.FindAllStringIndex(last, -1); len(locs) > 0 {
+			loc := locs[len(locs)-1]
+			holders = appendHolder(holders, last[:loc[0]])
+			last = last[loc[1]:]
+		}
+	}
+	return appendHolder(holders, last)
+}
+
+// appendHolder cleans part of statement and appends it to holders if it's a holder
+func appendHolder(holders []string, part string) []string {
+	holder := strings.Join(strings.Fields(part), " ")
+	holder = holderTrimPattern.ReplaceAllString(holder, "")
+	// Full stop ends the statement, but abbreviations like "Inc." keep it
+	if !abbreviationPattern.MatchString(lastWord(holder)) {
+		holder = strings.TrimSpace(strings.TrimSuffix(holder, "."))
+	}
+	if holder == "" || strings.EqualFold(holder, "others") {
+		return holders
+	}
+	return append(holders, holder)
+}
+
+func lastWord(text string) string {
+	fields := strings.Fields(text)
+	if len(fields) == 0 {
+		return ""
+	}
+	return fields[len(fields)-1]
+}
@@ -0,0 +1,179 @@
+package licensechecker
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExtractCopyrights(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []Copyright
+	}{
+		{
+			name:    "(c) with single year",
+			content: exampleMITContent,
+			want: []Copyright{
+				{
+					Holders: []string{"Thuc Le"},
+					Years:   []YearRange{{From: 2019, To: 2019}},
+					Emails:  []string{},
+					Line:    "Copyright (c) 2019 Thuc Le",
+					Start:   13,
+					End:     39,
+				},
+			},
+		},
+		{
+			name:    "© with year range, email and all rights reserved",
+			content: "© 2015-2019 Thuc Le <thuc@example.com>. All rights reserved.",
+			want: []Copyright{
+				{
+					Holders:           []string{"Thuc Le"},
+					Years:             []YearRange{{From: 2015, To: 2019}},
+					Emails:            []string{"thuc@example.com"},
+					AllRightsReserved: true,
+					Line:              "© 2015-2019 Thuc Le <thuc@example.com>. All rights reserved.",
+					Start:             0,
+					End:               61,
+				},
+			},
+		},
+		{
+			name:    "Copyright (C) with several years and company",
+			content: "Copyright (C) 2009, 2012-14, 2018-present Example, Inc.",
+			want: []Copyright{
+				{
+					Holders: []string{"Example, Inc."},
+					Years: []YearRange{
+						{From: 2009, To: 2009},
+						{From: 2012, To: 2014},
+						{From: 2018, To: 0},
+					},
+					Emails: []string{},
+					Line:   "Copyright (C) 2009, 2012-14, 2018-present Example, Inc.",
+					Start:  0,
+					End:    55,
+				},
+			},
+		},
+		{
+			name: "Multi-line holders in source comment",
+			content: `// Copyright 2018 Alice <alice@example.com>,
+//                Bob <bob@example.com> and
+//                Carol
+package main`,
+			want: []Copyright{
+				{
+					Holders: []string{"Alice", "Bob", "Carol"},
+					Years:   []YearRange{{From: 2018, To: 2018}},
+					Emails:  []string{"alice@example.com", "bob@example.com"},
+					Line: `// Copyright 2018 Alice <alice@example.com>,
+//                Bob <bob@example.com> and
+//                Carol`,
+					Start: 0,
+					End:   112,
+				},
+			},
+		},
+		{
+			name: "Several statements",
+			content: `/*
+ * Copyright (c) 2010 The Example Authors
+ * Copyright 2020 Someone Else; Another Person
+ */`,
+			want: []Copyright{
+				{
+					Holders: []string{"The Example Authors"},
+					Years:   []YearRange{{From: 2010, To: 2010}},
+					Emails:  []string{},
+					Line:    " * Copyright (c) 2010 The Example Authors",
+					Start:   3,
+					End:     44,
+				},
+				{
+					Holders: []string{"Someone Else", "Another Person"},
+					Years:   []YearRange{{From: 2020, To: 2020}},
+					Emails:  []string{},
+					Line:    " * Copyright 2020 Someone Else; Another Person",
+					Start:   45,
+					End:     91,
+				},
+			},
+		},
+		{
+			name:    "Conjunctions inside of holder names",
+			content: "Copyright 2019 Johnson and Johnson\nCopyright 2020 AT&T, Procter & Gamble\nCopyright 2021 Google, Johnson & Johnson",
+			want: []Copyright{
+				{
+					Holders: []string{"Johnson and Johnson"},
+					Years:   []YearRange{{From: 2019, To: 2019}},
+					Emails:  []string{},
+					Line:    "Copyright 2019 Johnson and Johnson",
+					Start:   0,
+					End:     34,
+				},
+				{
+					Holders: []string{"AT&T", "Procter & Gamble"},
+					Years:   []YearRange{{From: 2020, To: 2020}},
+					Emails:  []string{},
+					Line:    "Copyright 2020 AT&T, Procter & Gamble",
+					Start:   35,
+					End:     72,
+				},
+				{
+					Holders: []string{"Google", "Johnson & Johnson"},
+					Years:   []YearRange{{From: 2021, To: 2021}},
+					Emails:  []string{},
+					Line:    "Copyright 2021 Google, Johnson & Johnson",
+					Start:   73,
+					End:     113,
+				},
+			},
+		},
+		{
+			name:    "And joins the last holders of a list",
+			content: "Copyright 2018 Alice, Bob and Carol",
+			want: []Copyright{
+				{
+					Holders: []string{"Alice", "Bob", "Carol"},
+					Years:   []YearRange{{From: 2018, To: 2018}},
+					Emails:  []string{},
+					Line:    "Copyright 2018 Alice, Bob and Carol",
+					Start:   0,
+					End:     35,
+				},
+			},
+		},
+		{
+			name:    "Sentence after comma doesn't continue statement",
+			content: "Copyright (c) 2019 Thuc Le, Alice,\nPermission is hereby granted, free of charge, to any person",
+			want: []Copyright{
+				{
+					Holders: []string{"Thuc Le", "Alice"},
+					Years:   []YearRange{{From: 2019, To: 2019}},
+					Emails:  []string{},
+					Line:    "Copyright (c) 2019 Thuc Le, Alice,",
+					Start:   0,
+					End:     34,
+				},
+			},
+		},
+		{
+			name: "Sentences of license text aren't statements",
+			content: `Copyright notice and this permission notice shall be included.
+Copyright holders and contributors provide the software "as is".
+(c) You must retain all copyright notices.`,
+			want: []Copyright{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ExtractCopyrights([]byte(tt.content))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ExtractCopyrights() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
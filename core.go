@@ -31,7 +31,13 @@ func (e license) contains(info LicenseInfo) bool {
 		if info.LicenseID == exception.LicenseID &&
 			info.Name == exception.Name &&
 			info.IsDeprecated == exception.IsDeprecatedLicenseID &&
-			reflect.DeepEqual(info.References, exception.SeeAlso) {
+			reflect.DeepEqual(info.References, exception.SeeAlso) &&
+			info.Kind == KindLicense &&
+			info.IsOsiApproved == exception.IsOsiApproved &&
+			info.IsFsfLibre == exception.IsFsfLibre &&
+			info.Reference == exception.Reference &&
+			info.DetailsURL == exception.DetailsURL &&
+			info.ReferenceNumber == exception.ReferenceNumber {
 			return true
 		}
 	}
@@ -74,7 +80,11 @@ func (e exception) contains(info LicenseInfo) bool {
 		if info.LicenseID == exception.LicenseExceptionID &&
 			info.Name == exception.Name &&
 			info.IsDeprecated == exception.IsDeprecatedLicenseID &&
-			reflect.DeepEqual(info.References, exception.SeeAlso) {
+			reflect.DeepEqual(info.References, exception.SeeAlso) &&
+			info.Kind == KindException &&
+			info.Reference == exception.Reference &&
+			info.DetailsURL == exception.DetailsURL &&
+			info.ReferenceNumber == exception.ReferenceNumber {
 			return true
 		}
 	}
@@ -39,7 +39,12 @@ func Test_convertStandardLicenses(t *testing.T) {
 					References: []string{
 						"http://landley.net/toybox/license.html",
 					},
-					IsDeprecated: false,
+					IsDeprecated:    false,
+					Kind:            KindLicense,
+					IsOsiApproved:   true,
+					Reference:       "./0BSD.html",
+					DetailsURL:      "http://spdx.org/licenses/0BSD.json",
+					ReferenceNumber: "319",
 				},
 				"AAL": LicenseInfo{
 					LicenseID: "AAL",
@@ -47,7 +52,12 @@ func Test_convertStandardLicenses(t *testing.T) {
 					References: []string{
 						"https://opensource.org/licenses/attribution",
 					},
-					IsDeprecated: false,
+					IsDeprecated:    false,
+					Kind:            KindLicense,
+					IsOsiApproved:   true,
+					Reference:       "./AAL.html",
+					DetailsURL:      "http://spdx.org/licenses/AAL.json",
+					ReferenceNumber: "21",
 				},
 				"AGPL-3.0": LicenseInfo{
 					LicenseID: "AGPL-3.0",
@@ -56,7 +66,13 @@ func Test_convertStandardLicenses(t *testing.T) {
 						"https://www.gnu.org/licenses/agpl.txt",
 						"https://opensource.org/licenses/AGPL-3.0",
 					},
-					IsDeprecated: true,
+					IsDeprecated:    true,
+					Kind:            KindLicense,
+					IsOsiApproved:   true,
+					IsFsfLibre:      true,
+					Reference:       "./AGPL-3.0.html",
+					DetailsURL:      "http://spdx.org/licenses/AGPL-3.0.json",
+					ReferenceNumber: "229",
 				},
 			},
 		},
@@ -132,7 +148,11 @@ func Test_convertExceptionLicenses(t *testing.T) {
 					References: []string{
 						"http://git.savannah.gnu.org/cgit/libtool.git/tree/m4/libtool.m4",
 					},
-					IsDeprecated: false,
+					IsDeprecated:    false,
+					Kind:            KindException,
+					Reference:       "./Libtool-exception.html",
+					DetailsURL:      "http://spdx.org/licenses/Libtool-exception.json",
+					ReferenceNumber: "1",
 				},
 				"Classpath-exception-2.0": LicenseInfo{
 					LicenseID: "Classpath-exception-2.0",
@@ -141,7 +161,11 @@ func Test_convertExceptionLicenses(t *testing.T) {
 						"http://www.gnu.org/software/classpath/license.html",
 						"https://fedoraproject.org/wiki/Licensing/GPL_Classpath_Exception",
 					},
-					IsDeprecated: false,
+					IsDeprecated:    false,
+					Kind:            KindException,
+					Reference:       "./Classpath-exception-2.0.html",
+					DetailsURL:      "http://spdx.org/licenses/Classpath-exception-2.0.json",
+					ReferenceNumber: "13",
 				},
 				"Nokia-Qt-exception-1.1": LicenseInfo{
 					LicenseID: "Nokia-Qt-exception-1.1",
@@ -149,7 +173,11 @@ func Test_convertExceptionLicenses(t *testing.T) {
 					References: []string{
 						"https://www.keepassx.org/dev/projects/keepassx/repository/revisions/b8dfb9cc4d5133e0f09cd7533d15a4f1c19a40f2/entry/LICENSE.NOKIA-LGPL-EXCEPTION",
 					},
-					IsDeprecated: true,
+					IsDeprecated:    true,
+					Kind:            KindException,
+					Reference:       "./Nokia-Qt-exception-1.1.html",
+					DetailsURL:      "http://spdx.org/licenses/Nokia-Qt-exception-1.1.json",
+					ReferenceNumber: "23",
 				},
 			},
 		},
@@ -280,9 +308,15 @@ func Test_license_contains(t *testing.T) {
 			name: "Contains",
 			l:    exampleLicense,
 			info: LicenseInfo{
-				LicenseID:    "AGPL-3.0",
-				Name:         "GNU Affero General Public License v3.0",
-				IsDeprecated: true,
+				LicenseID:       "AGPL-3.0",
+				Name:            "GNU Affero General Public License v3.0",
+				IsDeprecated:    true,
+				Kind:            KindLicense,
+				IsOsiApproved:   true,
+				IsFsfLibre:      true,
+				Reference:       "./AGPL-3.0.html",
+				DetailsURL:      "http://spdx.org/licenses/AGPL-3.0.json",
+				ReferenceNumber: "229",
 				References: []string{
 					"https://www.gnu.org/licenses/agpl.txt",
 					"https://opensource.org/licenses/AGPL-3.0",
@@ -308,9 +342,15 @@ func Test_license_contains(t *testing.T) {
 			name: "Wrong Name",
 			l:    exampleLicense,
 			info: LicenseInfo{
-				LicenseID:    "AGPL-3.0",
-				Name:         "GNU Affero General Public License v3.1",
-				IsDeprecated: true,
+				LicenseID:       "AGPL-3.0",
+				Name:            "GNU Affero General Public License v3.1",
+				IsDeprecated:    true,
+				Kind:            KindLicense,
+				IsOsiApproved:   true,
+				IsFsfLibre:      true,
+				Reference:       "./AGPL-3.0.html",
+				DetailsURL:      "http://spdx.org/licenses/AGPL-3.0.json",
+				ReferenceNumber: "229",
 				References: []string{
 					"https://www.gnu.org/licenses/agpl.txt",
 					"https://opensource.org/licenses/AGPL-3.0",
@@ -322,9 +362,15 @@ func Test_license_contains(t *testing.T) {
 			name: "Wrong Deprecated",
 			l:    exampleLicense,
 			info: LicenseInfo{
-				LicenseID:    "AGPL-3.0",
-				Name:         "GNU Affero General Public License v3.0",
-				IsDeprecated: false,
+				LicenseID:       "AGPL-3.0",
+				Name:            "GNU Affero General Public License v3.0",
+				IsDeprecated:    false,
+				Kind:            KindLicense,
+				IsOsiApproved:   true,
+				IsFsfLibre:      true,
+				Reference:       "./AGPL-3.0.html",
+				DetailsURL:      "http://spdx.org/licenses/AGPL-3.0.json",
+				ReferenceNumber: "229",
 				References: []string{
 					"https://www.gnu.org/licenses/agpl.txt",
 					"https://opensource.org/licenses/AGPL-3.0",
@@ -336,9 +382,15 @@ func Test_license_contains(t *testing.T) {
 			name: "Wrong References - different",
 			l:    exampleLicense,
 			info: LicenseInfo{
-				LicenseID:    "AGPL-3.0",
-				Name:         "GNU Affero General Public License v3.0",
-				IsDeprecated: true,
+				LicenseID:       "AGPL-3.0",
+				Name:            "GNU Affero General Public License v3.0",
+				IsDeprecated:    true,
+				Kind:            KindLicense,
+				IsOsiApproved:   true,
+				IsFsfLibre:      true,
+				Reference:       "./AGPL-3.0.html",
+				DetailsURL:      "http://spdx.org/licenses/AGPL-3.0.json",
+				ReferenceNumber: "229",
 				References: []string{
 					"https://www.gnu.org/licenses/agpl.json",
 					"https://opensource.org/licenses/AGPL-3.0",
@@ -350,9 +402,15 @@ func Test_license_contains(t *testing.T) {
 			name: "Wrong References - miss",
 			l:    exampleLicense,
 			info: LicenseInfo{
-				LicenseID:    "AGPL-3.0",
-				Name:         "GNU Affero General Public License v3.0",
-				IsDeprecated: true,
+				LicenseID:       "AGPL-3.0",
+				Name:            "GNU Affero General Public License v3.0",
+				IsDeprecated:    true,
+				Kind:            KindLicense,
+				IsOsiApproved:   true,
+				IsFsfLibre:      true,
+				Reference:       "./AGPL-3.0.html",
+				DetailsURL:      "http://spdx.org/licenses/AGPL-3.0.json",
+				ReferenceNumber: "229",
 				References: []string{
 					"https://opensource.org/licenses/AGPL-3.0",
 				},
@@ -363,9 +421,15 @@ func Test_license_contains(t *testing.T) {
 			name: "Wrong References - more",
 			l:    exampleLicense,
 			info: LicenseInfo{
-				LicenseID:    "AGPL-3.0",
-				Name:         "GNU Affero General Public License v3.0",
-				IsDeprecated: true,
+				LicenseID:       "AGPL-3.0",
+				Name:            "GNU Affero General Public License v3.0",
+				IsDeprecated:    true,
+				Kind:            KindLicense,
+				IsOsiApproved:   true,
+				IsFsfLibre:      true,
+				Reference:       "./AGPL-3.0.html",
+				DetailsURL:      "http://spdx.org/licenses/AGPL-3.0.json",
+				ReferenceNumber: "229",
 				References: []string{
 					"https://www.gnu.org/licenses/agpl.txt",
 					"https://opensource.org/licenses/AGPL-3.0",
@@ -401,9 +465,13 @@ func Test_exception_contains(t *testing.T) {
 			name: "Contains",
 			e:    exampleExceptionLicense,
 			info: LicenseInfo{
-				LicenseID:    "Libtool-exception",
-				Name:         "Libtool Exception",
-				IsDeprecated: false,
+				LicenseID:       "Libtool-exception",
+				Name:            "Libtool Exception",
+				IsDeprecated:    false,
+				Kind:            KindException,
+				Reference:       "./Libtool-exception.html",
+				DetailsURL:      "http://spdx.org/licenses/Libtool-exception.json",
+				ReferenceNumber: "1",
 				References: []string{
 					"http://git.savannah.gnu.org/cgit/libtool.git/tree/m4/libtool.m4",
 				},
@@ -427,9 +495,13 @@ func Test_exception_contains(t *testing.T) {
 			name: "Wrong Name",
 			e:    exampleExceptionLicense,
 			info: LicenseInfo{
-				LicenseID:    "Libtool-exception",
-				Name:         "Libtool 123 Exception",
-				IsDeprecated: false,
+				LicenseID:       "Libtool-exception",
+				Name:            "Libtool 123 Exception",
+				IsDeprecated:    false,
+				Kind:            KindException,
+				Reference:       "./Libtool-exception.html",
+				DetailsURL:      "http://spdx.org/licenses/Libtool-exception.json",
+				ReferenceNumber: "1",
 				References: []string{
 					"http://git.savannah.gnu.org/cgit/libtool.git/tree/m4/libtool.m4",
 				},
@@ -440,9 +512,13 @@ func Test_exception_contains(t *testing.T) {
 			name: "Wrong Deprecated",
 			e:    exampleExceptionLicense,
 			info: LicenseInfo{
-				LicenseID:    "Libtool-exception",
-				Name:         "Libtool Exception",
-				IsDeprecated: true,
+				LicenseID:       "Libtool-exception",
+				Name:            "Libtool Exception",
+				IsDeprecated:    true,
+				Kind:            KindException,
+				Reference:       "./Libtool-exception.html",
+				DetailsURL:      "http://spdx.org/licenses/Libtool-exception.json",
+				ReferenceNumber: "1",
 				References: []string{
 					"http://git.savannah.gnu.org/cgit/libtool.git/tree/m4/libtool.m4",
 				},
@@ -453,9 +529,13 @@ func Test_exception_contains(t *testing.T) {
 			name: "Wrong References - different",
 			e:    exampleExceptionLicense,
 			info: LicenseInfo{
-				LicenseID:    "Libtool-exception",
-				Name:         "Libtool Exception",
-				IsDeprecated: false,
+				LicenseID:       "Libtool-exception",
+				Name:            "Libtool Exception",
+				IsDeprecated:    false,
+				Kind:            KindException,
+				Reference:       "./Libtool-exception.html",
+				DetailsURL:      "http://spdx.org/licenses/Libtool-exception.json",
+				ReferenceNumber: "1",
 				References: []string{
 					"http://git.savannah.gnu.org/cgit/libtool.git/tree/m4/libtool.m5",
 				},
@@ -466,10 +546,14 @@ func Test_exception_contains(t *testing.T) {
 			name: "Wrong References - miss",
 			e:    exampleExceptionLicense,
 			info: LicenseInfo{
-				LicenseID:    "Libtool-exception",
-				Name:         "Libtool Exception",
-				IsDeprecated: true,
-				References:   []string{},
+				LicenseID:       "Libtool-exception",
+				Name:            "Libtool Exception",
+				IsDeprecated:    true,
+				Kind:            KindException,
+				Reference:       "./Libtool-exception.html",
+				DetailsURL:      "http://spdx.org/licenses/Libtool-exception.json",
+				ReferenceNumber: "1",
+				References:      []string{},
 			},
 			want: false,
 		},
@@ -477,9 +561,13 @@ func Test_exception_contains(t *testing.T) {
 			name: "Wrong References - more",
 			e:    exampleExceptionLicense,
 			info: LicenseInfo{
-				LicenseID:    "Libtool-exception",
-				Name:         "Libtool Exception",
-				IsDeprecated: true,
+				LicenseID:       "Libtool-exception",
+				Name:            "Libtool Exception",
+				IsDeprecated:    true,
+				Kind:            KindException,
+				Reference:       "./Libtool-exception.html",
+				DetailsURL:      "http://spdx.org/licenses/Libtool-exception.json",
+				ReferenceNumber: "1",
 				References: []string{
 					"http://git.savannah.gnu.org/cgit/libtool.git/tree/m4/libtool.m4",
 					"http://git.savannah.gnu.org/cgit/libtool.git/tree/m4/libtool.m5",
@@ -0,0 +1,92 @@
+package licensechecker
+
+import (
+	"regexp"
+)
+
+// expressionIDPattern matches license and exception IDs of SPDX expression
+var expressionIDPattern = regexp.MustCompile(`[\w.+-]+`)
+
+// deprecatedSuccessors are current SPDX expressions of deprecated license and exception IDs. IDs without "+"
+// mean the "-only" version, and IDs that combine a license with an exception become WITH expressions.
+var deprecatedSuccessors = map[string]string{
+	"AGPL-1.0": "AGPL-1.0-only",
+	"AGPL-3.0": "AGPL-3.0-only",
+
+	"GFDL-1.1": "GFDL-1.1-only",
+	"GFDL-1.2": "GFDL-1.2-only",
+	"GFDL-1.3": "GFDL-1.3-only",
+
+	"GPL-1.0":  "GPL-1.0-only",
+	"GPL-1.0+": "GPL-1.0-or-later",
+	"GPL-2.0":  "GPL-2.0-only",
+	"GPL-2.0+": "GPL-2.0-or-later",
+	"GPL-3.0":  "GPL-3.0-only",
+	"GPL-3.0+": "GPL-3.0-or-later",
+
+	"GPL-2.0-with-GCC-exception":       "GPL-2.0-only WITH GCC-exception-2.0",
+	"GPL-2.0-with-autoconf-exception":  "GPL-2.0-only WITH Autoconf-exception-2.0",
+	"GPL-2.0-with-bison-exception":     "GPL-2.0-only WITH Bison-exception-2.2",
+	"GPL-2.0-with-classpath-exception": "GPL-2.0-only WITH Classpath-exception-2.0",
+	"GPL-2.0-with-font-exception":      "GPL-2.0-only WITH Font-exception-2.0",
+	"GPL-3.0-with-GCC-exception":       "GPL-3.0-only WITH GCC-exception-3.1",
+	"GPL-3.0-with-autoconf-exception":  "GPL-3.0-only WITH Autoconf-exception-3.0",
+
+	"LGPL-2.0":  "LGPL-2.0-only",
+	"LGPL-2.0+": "LGPL-2.0-or-later",
+	"LGPL-2.1":  "LGPL-2.1-only",
+	"LGPL-2.1+": "LGPL-2.1-or-later",
+	"LGPL-3.0":  "LGPL-3.0-only",
+	"LGPL-3.0+": "LGPL-3.0-or-later",
+
+	"Nunit":         "zlib-acknowledgement",
+	"StandardML-NJ": "SMLNJ",
+	"eCos-2.0":      "GPL-2.0-or-later WITH eCos-exception-2.0",
+	"wxWindows":     "LGPL-2.0-or-later WITH WxWindows-exception-3.1",
+
+	"Nokia-Qt-exception-1.1": "Qt-LGPL-exception-1.1",
+}
+
+// ResolveDeprecatedID returns the current SPDX expression of a deprecated license or exception ID, e.g. "GPL-2.0+"
+// is "GPL-2.0-or-later". The second result is false if the ID isn't a known deprecated ID, and the ID is returned as is.
+func ResolveDeprecatedID(licenseID string) (string, bool) {
+	if successor, ok := deprecatedSuccessors[licenseID]; ok {
+		return successor, true
+	}
+	return licenseID, false
+}
+
+// resolveDeprecatedIDs replaces deprecated IDs of an expression by their successors, e.g. "GPL-2.0+ OR MIT" is
+// "GPL-2.0-or-later OR MIT". WITH binds tighter than AND and OR, so WITH successors don't need parentheses.
+func resolveDeprecatedIDs(expression string) string {
+	return expressionIDPattern.ReplaceAllStringFunc(expression, func(licenseID string) string {
+		successor, _ := ResolveDeprecatedID(licenseID)
+		return successor
+	})
+}
+
+// resolveDeprecated replaces deprecated license and exception of a match by their successors. A deprecated license
+// can be replaced by a license with an exception, then the exception fills the empty exception of the match.
+func (c corpus) resolveDeprecated(license, exception LicenseInfo) (LicenseInfo, LicenseInfo) {
+	if license.IsDeprecated {
+		if match := simpleExpressionPattern.FindStringSubmatch(deprecatedSuccessors[license.LicenseID]); match != nil {
+			if info, ok := c.find(match[1]); ok {
+				license = info
+			}
+			if info, ok := c.find(match[2]); ok && exception.LicenseID == "" {
+				exception = info
+			}
+		}
+	}
+	if exception.IsDeprecated {
+		if info, ok := c.find(deprecatedSuccessors[exception.LicenseID]); ok {
+			exception = info
+		}
+	}
+	return license, exception
+}
+
+// preferredOver breaks ties of matches, a current license is preferred over a deprecated one
+func preferredOver(info, other LicenseInfo) bool {
+	return !info.IsDeprecated && other.IsDeprecated
+}
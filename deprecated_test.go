@@ -0,0 +1,92 @@
+package licensechecker
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ledongthuc/licensechecker/internal/data"
+)
+
+func TestResolveDeprecatedID(t *testing.T) {
+	tests := []struct {
+		licenseID  string
+		want       string
+		deprecated bool
+	}{
+		{licenseID: "GPL-2.0", want: "GPL-2.0-only", deprecated: true},
+		{licenseID: "GPL-2.0+", want: "GPL-2.0-or-later", deprecated: true},
+		{licenseID: "wxWindows", want: "LGPL-2.0-or-later WITH WxWindows-exception-3.1", deprecated: true},
+		{licenseID: "Nokia-Qt-exception-1.1", want: "Qt-LGPL-exception-1.1", deprecated: true},
+		{licenseID: "MIT", want: "MIT", deprecated: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.licenseID, func(t *testing.T) {
+			got, deprecated := ResolveDeprecatedID(tt.licenseID)
+			if got != tt.want || deprecated != tt.deprecated {
+				t.Errorf("ResolveDeprecatedID() = %v, %v, want %v, %v", got, deprecated, tt.want, tt.deprecated)
+			}
+		})
+	}
+}
+
+func Test_resolveDeprecatedIDs(t *testing.T) {
+	tests := []struct {
+		expression string
+		want       string
+	}{
+		{expression: "GPL-2.0+ OR MIT", want: "GPL-2.0-or-later OR MIT"},
+		{expression: "(LGPL-2.1 AND wxWindows)", want: "(LGPL-2.1-only AND LGPL-2.0-or-later WITH WxWindows-exception-3.1)"},
+		{expression: "Apache-2.0 WITH LLVM-exception", want: "Apache-2.0 WITH LLVM-exception"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.expression, func(t *testing.T) {
+			if got := resolveDeprecatedIDs(tt.expression); got != tt.want {
+				t.Errorf("resolveDeprecatedIDs() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDeprecatedSuccessorsAreCurrent(t *testing.T) {
+	c, err := loadCorpus()
+	if err != nil {
+		t.Errorf("loadCorpus() error = %v", err)
+		return
+	}
+	for _, entry := range c.entries {
+		if entry.info.IsDeprecated {
+			if _, ok := deprecatedSuccessors[entry.info.LicenseID]; !ok {
+				t.Errorf("deprecated %s doesn't have successor", entry.info.LicenseID)
+			}
+		}
+	}
+	for licenseID, successor := range deprecatedSuccessors {
+		for _, part := range strings.Split(successor, " WITH ") {
+			info, ok := c.find(part)
+			if !ok || info.IsDeprecated {
+				t.Errorf("successor %s of %s isn't a current ID", part, licenseID)
+			}
+		}
+	}
+}
+
+func TestDetect_deprecated(t *testing.T) {
+	contentGPL, _ := data.Asset("GPL-2.0-only.txt")
+	got, err := DetectWithOptions(contentGPL, DetectOptions{NumberOfCandidates: 3})
+	if err != nil {
+		t.Errorf("DetectWithOptions() error = %v", err)
+		return
+	}
+	if got.IsDeprecated || got.LicenseID != "GPL-2.0-only" || got.Scope != ScopeAmbiguous {
+		t.Errorf("DetectWithOptions() = %v, %v, want a current license GPL-2.0-only of ambiguous scope", got.LicenseID, got.Scope)
+	}
+
+	source, err := DetectSource([]byte("// SPDX-License-Identifier: GPL-2.0+\npackage main\n"), "main.go")
+	if err != nil {
+		t.Errorf("DetectSource() error = %v", err)
+		return
+	}
+	if source.SPDXIdentifier != "GPL-2.0+" || source.Expression != "GPL-2.0-or-later" {
+		t.Errorf("DetectSource() = %v, expression %v, want GPL-2.0+ and GPL-2.0-or-later", source.SPDXIdentifier, source.Expression)
+	}
+}
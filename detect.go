@@ -0,0 +1,410 @@
+package licensechecker
+
+import (
+	"sort"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/ledongthuc/licensechecker/format"
+	"github.com/ledongthuc/licensechecker/normalize"
+	"github.com/ledongthuc/licensechecker/template"
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+const (
+	// numberOfShortlist is minimum number of candidates that go from the n-gram index into word alignment
+	numberOfShortlist = 5
+	// MinimumConfidence is the lowest confidence that we still consider as a match
+	MinimumConfidence = 0.5
+)
+
+// DetectOptions controls how detection works
+type DetectOptions struct {
+	// NumberOfCandidates is maximum number of alternative candidates returned beside the best match. 0 means no alternative.
+	NumberOfCandidates int
+	// Format is markup language of content, which is stripped before detection. format.Unknown, the zero value, is
+	// sniffed from content, and every entry point that doesn't take options sniffs too, e.g. Detect, DetectRegions
+	// and detectors of Chain. Use format.Plain to skip sniffing and stripping.
+	Format format.Format
+	// Explain adds evidence trail of the detection into result, see Explanation
+	Explain bool
+}
+
+// Candidate is a license that can match with inputted content, and how much it matches
+type Candidate struct {
+	LicenseInfo
+	// Confidence is from 0 to 1, 1 means the license text is found exactly in the content
+	Confidence float64
+	// Coverage is fraction of inputted content, from 0 to 1, that's covered by the license text
+	Coverage float64
+}
+
+// DetectResult contains the best matched license and other alternative candidates ordered by confidence
+type DetectResult struct {
+	Candidate
+	// Variables are values captured by variable regions of license template, e.g. "holder" and "year"
+	Variables map[string]string
+	// Exception is the license exception that follows the license text, its LicenseID is empty if there isn't any
+	Exception LicenseInfo
+	// Expression is SPDX license expression of the match, e.g. "MIT" or "GPL-2.0-only WITH Classpath-exception-2.0"
+	Expression string
+	// Scope tells whether a GNU license is "-only" or "-or-later" by the notice around the license text
+	Scope Scope
+	// Deviations are word-level differences between the content and text of the matched license
+	Deviations []Deviation
+	// Modified is true if any deviation is substantive. Content that matches the license template isn't modified.
+	Modified   bool
+	Candidates []Candidate
+	// Explanation is evidence trail of the detection, it's nil unless DetectOptions.Explain is set
+	Explanation *Explanation
+	// Detector is name of the detector that produced result, it's set by Chain
+	Detector string
+	// ReferencedURLs are URLs in content that are references of licenses, e.g. "https://opensource.org/licenses/MIT".
+	// They're evidence beside the detected license, and they're reported even if there isn't any match.
+	ReferencedURLs []ReferencedURL
+}
+
+// corpusEntry keeps a license of spdx in prepared form for detection
+type corpusEntry struct {
+	info      LicenseInfo
+	words     []string
+	exception bool
+	// clauses are broken from license text when it's explained first time, see licenseClauses
+	clauses *clauseCache
+}
+
+// corpus contains all licenses and exceptions that detection compares against
+type corpus struct {
+	entries []corpusEntry
+	index   ngramIndex
+	// names maps keys of license IDs and names into entries
+	names map[string]int
+	// urls maps keys of license references into entries, see urlKey
+	urls map[string][]int
+	// fingerprints maps fingerprints of license texts into license IDs, see findVerbatim
+	fingerprints map[string]string
+}
+
+var (
+	loadedCorpusOnce sync.Once
+	loadedCorpus     corpus
+	loadedCorpusErr  error
+)
+
+// loadCorpus loads all licenses and exceptions from assets and builds their n-gram index once, then re-uses them for next detections
+func loadCorpus() (corpus, error) {
+	loadedCorpusOnce.Do(func() {
+		licenses, err := All()
+		if err != nil {
+			loadedCorpusErr = err
+			return
+		}
+		// A license goes before an exception with the same ID
+		sort.SliceStable(licenses, func(i, j int) bool {
+			return licenses[i].LicenseInfo.LicenseID < licenses[j].LicenseInfo.LicenseID
+		})
+
+		entries := make([]corpusEntry, 0, len(licenses))
+		for _, l := range licenses {
+			words := splitWords(l.Content)
+			if len(words) == 0 {
+				continue
+			}
+			entries = append(entries, corpusEntry{
+				info:      l.LicenseInfo,
+				words:     words,
+				exception: l.LicenseInfo.Kind == KindException,
+				clauses:   &clauseCache{},
+			})
+		}
+		loadedCorpus = corpus{
+			entries:      entries,
+			index:        newNgramIndex(entries),
+			names:        newNameIndex(entries),
+			urls:         newURLIndex(entries),
+			fingerprints: newFingerprintTable(entries),
+		}
+	})
+	return loadedCorpus, loadedCorpusErr
+}
+
+// find looks up a license or exception of corpus by its ID
+func (c corpus) find(licenseID string) (LicenseInfo, bool) {
+	index := sort.Search(len(c.entries), func(i int) bool {
+		return c.entries[i].info.LicenseID >= licenseID
+	})
+	if index < len(c.entries) && c.entries[index].info.LicenseID == licenseID {
+		return c.entries[index].info, true
+	}
+	return LicenseInfo{}, false
+}
+
+// detect ranks licenses by their confidence with inputted content. A verbatim copy of license text is found by its
+// fingerprint first. Otherwise, it shortlists candidates by the n-gram index, then aligns words of shortlisted ones
+// to get confidence and coverage. If the best match has a license template, and the content matches the template,
+// confidence is 1 and variables of template are captured.
+// An exception text after the best license is detected too, and they're combined into a WITH expression.
+// URLs of content that are references of licenses are reported as evidence.
+func (c corpus) detect(content []byte, options DetectOptions) (DetectResult, error) {
+	result, err := c.detectText(content, options)
+	result.ReferencedURLs = c.findReferencedURLs(content)
+	return result, err
+}
+
+// detectText finds the best license of content by its words, see detect
+func (c corpus) detectText(content []byte, options DetectOptions) (DetectResult, error) {
+	if options.Format == format.Unknown {
+		options.Format = format.Sniff(content, "")
+	}
+	text := format.Strip(content, options.Format)
+	tokens := normalize.Tokenize(text.Text)
+	words := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		words = append(words, token.Text)
+	}
+	if len(words) == 0 {
+		return DetectResult{}, ErrorNoMatch
+	}
+	// Verbatim copies are found by fingerprint before fuzzy matching, unless alternatives or explanation are wanted
+	if options.NumberOfCandidates == 0 && !options.Explain {
+		if result, err := c.findVerbatim(words); err == nil {
+			result.Variables, _ = matchTemplate(result.LicenseID, text.Text)
+			return result, nil
+		}
+	}
+
+	limit := numberOfShortlist
+	if options.NumberOfCandidates+1 > limit {
+		limit = options.NumberOfCandidates + 1
+	}
+	shortlist := c.index.shortlist(words, limit)
+
+	candidates := make([]Candidate, 0, len(shortlist))
+	regions := make(map[string]regionCandidate, len(shortlist))
+	for _, item := range shortlist {
+		entry := c.entries[item.index]
+		a := alignWords(words, entry.words, item.first, item.last)
+		regions[entry.info.LicenseID] = regionOfAlignment(entry, a)
+		candidates = append(candidates, Candidate{
+			LicenseInfo: entry.info,
+			Confidence:  a.confidence(len(entry.words)),
+			Coverage:    a.coverage(len(words)),
+		})
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Confidence != candidates[j].Confidence {
+			return candidates[i].Confidence > candidates[j].Confidence
+		}
+		return preferredOver(candidates[i].LicenseInfo, candidates[j].LicenseInfo)
+	})
+
+	result := DetectResult{}
+	if options.Explain {
+		count := options.NumberOfCandidates + 1
+		if count < minimumExplainedCandidates {
+			count = minimumExplainedCandidates
+		}
+		explained := candidates
+		if len(explained) > count {
+			explained = explained[:count]
+		}
+		result.Explanation = explain(content, options.Format, text, tokens, explained, regions)
+	}
+	if len(candidates) > 0 && candidates[0].Confidence >= MinimumConfidence {
+		result.Candidate = candidates[0]
+		candidates = candidates[1:]
+	}
+	if len(candidates) > options.NumberOfCandidates {
+		candidates = candidates[:options.NumberOfCandidates]
+	}
+	result.Candidates = candidates
+
+	if result.LicenseID == "" {
+		return result, ErrorNoMatch
+	}
+
+	region := regions[result.LicenseID]
+	if !region.entry.exception {
+		if exception, ok := c.findException(words[region.last:]); ok {
+			result.Exception = exception.entry.info
+		}
+	}
+	// Words after the license text are appended to it, e.g. a rider that restricts the license, unless they're an exception
+	last := len(words)
+	if result.Exception.LicenseID != "" {
+		last = region.last
+	}
+	result.Deviations = findDeviations(tokens, region.entry.words, region.diffs, region.first, last)
+	for index, deviation := range result.Deviations {
+		result.Deviations[index].Start, result.Deviations[index].End = text.Span(deviation.Start, deviation.End)
+	}
+	result.LicenseInfo, result.Exception = c.resolveDeprecated(result.LicenseInfo, result.Exception)
+	// Words outside of the license text are the notice that decides between "-only" and "-or-later"
+	notice := append(append([]string{}, words[:region.first]...), words[region.last:]...)
+	result.LicenseInfo, result.Scope = c.withScope(result.LicenseInfo, noticeScope(notice))
+	result.Expression = licenseExpression(result.LicenseInfo, result.Exception)
+
+	// The template must match the whole license text, so text inserted into the license or appended to it isn't hidden
+	if variables, ok := matchTemplate(result.LicenseID, regionText(text.Text, tokens, region.first, last)); ok {
+		result.Confidence = 1
+		result.Variables = variables
+		// Deviations are variables and optional parts of the template
+		for index := range result.Deviations {
+			result.Deviations[index].Substantive = false
+		}
+	}
+	result.Modified = isModified(result.Deviations)
+	return result, nil
+}
+
+// matchTemplate matches text with template of license, and returns captured values of template variables
+func matchTemplate(licenseID, text string) (map[string]string, bool) {
+	markup, ok := template.FindByLicenseID(licenseID)
+	if !ok {
+		return nil, false
+	}
+	return markup.Match(text)
+}
+
+// regionText is text of words [first, last) of tokens, together with text before the first word that isn't any word,
+// e.g. the title and copyright notice of license
+func regionText(text string, tokens []normalize.Word, first, last int) string {
+	if first >= last {
+		return ""
+	}
+	start := 0
+	if first > 0 {
+		start = tokens[first-1].End
+	}
+	return text[start:tokens[last-1].End]
+}
+
+// splitWords normalizes content and breaks it into words
+func splitWords(content []byte) []string {
+	return normalize.Words(string(content))
+}
+
+// wordAlignment is result of word-level comparison between inputted content and a license text
+type wordAlignment struct {
+	// matched is number of words that are the same in both of them
+	matched int
+	// first and last are the range [first, last) of inputted words, from the first matched word to the last one
+	first, last int
+	// chunks are runs of matched words, by their positions in inputted words
+	chunks []matchedChunk
+	diffs  []diffmatchpatch.Diff
+}
+
+// matchedChunk is a run of matched words starting at position of inputted words
+type matchedChunk struct {
+	start, length int
+}
+
+// alignWords compares inputted words of window [first, last) with license words. Words are mapped into runes, so diff
+// works by words instead of characters. Diff isn't limited by time, so results don't depend on machine load, and words
+// outside of the window are deleted words of diffs.
+func alignWords(input, license []string, first, last int) wordAlignment {
+	dictionary := make(map[string]rune)
+	toRunes := func(words []string) []rune {
+		runes := make([]rune, 0, len(words))
+		for _, word := range words {
+			r, ok := dictionary[word]
+			if !ok {
+				// Start from supplementary planes to avoid invalid surrogate runes
+				r = rune(0x10000 + len(dictionary))
+				dictionary[word] = r
+			}
+			runes = append(runes, r)
+		}
+		return runes
+	}
+
+	dmp := diffmatchpatch.New()
+	dmp.DiffTimeout = 0
+	diffs := dmp.DiffMainRunes(toRunes(input[first:last]), toRunes(license), false)
+	if first > 0 {
+		diffs = append([]diffmatchpatch.Diff{{Type: diffmatchpatch.DiffDelete, Text: string(toRunes(input[:first]))}}, diffs...)
+	}
+	if last < len(input) {
+		diffs = append(diffs, diffmatchpatch.Diff{Type: diffmatchpatch.DiffDelete, Text: string(toRunes(input[last:]))})
+	}
+	result := wordAlignment{first: -1, diffs: diffs}
+	position := 0
+	for _, diff := range diffs {
+		length := utf8.RuneCountInString(diff.Text)
+		switch diff.Type {
+		case diffmatchpatch.DiffEqual:
+			if result.first < 0 {
+				result.first = position
+			}
+			result.matched += length
+			result.chunks = append(result.chunks, matchedChunk{start: position, length: length})
+			position += length
+			result.last = position
+		case diffmatchpatch.DiffDelete:
+			position += length
+		}
+	}
+	if result.first < 0 {
+		result.first = 0
+	}
+	return result
+}
+
+// confidence is Dice coefficient between license words and the matched region of inputted words
+func (a wordAlignment) confidence(licenseLength int) float64 {
+	total := licenseLength + a.last - a.first
+	if total == 0 {
+		return 0
+	}
+	return 2 * float64(a.matched) / float64(total)
+}
+
+// coverage is fraction of inputted words that are inside the matched region
+func (a wordAlignment) coverage(inputLength int) float64 {
+	if inputLength == 0 {
+		return 0
+	}
+	return float64(a.last-a.first) / float64(inputLength)
+}
+
+// densestCluster limits alignment into the group of chunks that has the most matched words, chunks of a group are
+// not farther than maxGap words from each other. It drops words that match by chance far from the license region.
+// Neighbour groups that have more matched words than their gap to the cluster are parts of the license around a long
+// inserted text, so they're kept in the cluster.
+func (a wordAlignment) densestCluster(maxGap int) wordAlignment {
+	groups := []wordAlignment{}
+	for _, chunk := range a.chunks {
+		if len(groups) == 0 || chunk.start-groups[len(groups)-1].last > maxGap {
+			groups = append(groups, wordAlignment{first: chunk.start})
+		}
+		group := &groups[len(groups)-1]
+		group.chunks = append(group.chunks, chunk)
+		group.matched += chunk.length
+		group.last = chunk.start + chunk.length
+	}
+	if len(groups) == 0 {
+		return wordAlignment{diffs: a.diffs}
+	}
+
+	best := 0
+	for index, group := range groups {
+		if group.matched > groups[best].matched {
+			best = index
+		}
+	}
+	first, last := best, best
+	for first > 0 && groups[first-1].matched >= groups[first].first-groups[first-1].last {
+		first--
+	}
+	for last+1 < len(groups) && groups[last+1].matched >= groups[last+1].first-groups[last].last {
+		last++
+	}
+
+	result := wordAlignment{first: groups[first].first, last: groups[last].last, diffs: a.diffs}
+	for _, group := range groups[first : last+1] {
+		result.chunks = append(result.chunks, group.chunks...)
+		result.matched += group.matched
+	}
+	return result
+}
@@ -0,0 +1,249 @@
+package licensechecker
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/ledongthuc/licensechecker/format"
+	"github.com/pkg/errors"
+)
+
+// Names of built-in detectors
+const (
+	DetectorExact   = "exact"
+	DetectorSPDXTag = "spdx-tag"
+	DetectorKeyword = "keyword"
+	DetectorFuzzy   = "fuzzy"
+	DetectorURL     = "url"
+)
+
+// keywordTitleLines is number of leading non-empty lines that keyword rules look at
+const keywordTitleLines = 3
+
+var (
+	ErrorUnknownDetector = errors.New("Can't find detector by the name")
+)
+
+// Detector finds license of content by one strategy. It returns ErrorNoMatch if the strategy can't decide,
+// so the next detector of chain can try.
+type Detector interface {
+	Name() string
+	Detect(content []byte) (DetectResult, error)
+}
+
+// Chain tries detectors in order and returns result of the first one that matches. Detectors can be registered,
+// removed and reordered, but a chain isn't safe for concurrent changes.
+type Chain struct {
+	detectors []Detector
+}
+
+// NewChain creates a chain of detectors in order
+func NewChain(detectors ...Detector) *Chain {
+	return &Chain{detectors: append([]Detector{}, detectors...)}
+}
+
+// DefaultChain creates the built-in chain: verbatim license text, SPDX identifier tag, fuzzy matching with all
+// licenses, keyword rules of license titles, then URLs of licenses. Fuzzy matching goes before keywords and URLs, so a
+// full license text is detected with its exception and scope instead of by its title only.
+func DefaultChain() *Chain {
+	return NewChain(ExactDetector{}, SPDXTagDetector{}, FuzzyDetector{}, NewKeywordDetector(DefaultKeywordRules...), URLDetector{})
+}
+
+// Register adds a detector at the end of chain
+func (c *Chain) Register(detector Detector) {
+	c.detectors = append(c.detectors, detector)
+}
+
+// Remove disables detector by its name, it returns false if chain doesn't have the detector
+func (c *Chain) Remove(name string) bool {
+	for index, detector := range c.detectors {
+		if detector.Name() == name {
+			c.detectors = append(c.detectors[:index], c.detectors[index+1:]...)
+			return true
+		}
+	}
+	return false
+}
+
+// Reorder keeps detectors of names in the order of names, detectors that aren't named are removed.
+// The chain isn't changed if any name is unknown.
+func (c *Chain) Reorder(names ...string) error {
+	detectors := make([]Detector, 0, len(names))
+	for _, name := range names {
+		found := false
+		for _, detector := range c.detectors {
+			if detector.Name() == name {
+				detectors = append(detectors, detector)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return errors.Wrap(ErrorUnknownDetector, "Error when reorder detector '"+name+"'")
+		}
+	}
+	c.detectors = detectors
+	return nil
+}
+
+// Names returns names of detectors in order
+func (c *Chain) Names() []string {
+	names := make([]string, 0, len(c.detectors))
+	for _, detector := range c.detectors {
+		names = append(names, detector.Name())
+	}
+	return names
+}
+
+// Detect runs detectors in order. Result of the first detector that matches is returned with its name in
+// DetectResult.Detector. Errors other than ErrorNoMatch stop the chain.
+func (c *Chain) Detect(content []byte) (DetectResult, error) {
+	for _, detector := range c.detectors {
+		result, err := detector.Detect(content)
+		if errors.Cause(err) == ErrorNoMatch {
+			continue
+		}
+		if err != nil {
+			return DetectResult{}, errors.Wrap(err, "Error when detect by '"+detector.Name()+"'")
+		}
+		result.Detector = detector.Name()
+		return result, nil
+	}
+	return DetectResult{}, ErrorNoMatch
+}
+
+// ExactDetector finds a verbatim copy of license text by fingerprint of its normalized words, see findVerbatim
+type ExactDetector struct{}
+
+func (ExactDetector) Name() string {
+	return DetectorExact
+}
+
+func (ExactDetector) Detect(content []byte) (DetectResult, error) {
+	c, err := loadCorpus()
+	if err != nil {
+		return DetectResult{}, err
+	}
+	return c.findVerbatim(splitWords([]byte(format.Strip(content, format.Unknown).Text)))
+}
+
+// SPDXTagDetector takes the expression of "SPDX-License-Identifier:" tag in content
+type SPDXTagDetector struct{}
+
+func (SPDXTagDetector) Name() string {
+	return DetectorSPDXTag
+}
+
+func (SPDXTagDetector) Detect(content []byte) (DetectResult, error) {
+	c, err := loadCorpus()
+	if err != nil {
+		return DetectResult{}, err
+	}
+	match := spdxIdentifierPattern.FindSubmatch(content)
+	if match == nil || len(match[1]) == 0 {
+		return DetectResult{}, ErrorNoMatch
+	}
+	return c.detectIdentifier(string(match[1])), nil
+}
+
+// KeywordRule recognizes license by its title, e.g. "Apache License" followed by "Version 2.0".
+// Pattern is matched with lower case title lines.
+type KeywordRule struct {
+	LicenseID string
+	Pattern   *regexp.Regexp
+}
+
+// DefaultKeywordRules recognize titles of common licenses. Titles are lines that start with license name, so
+// a mention of other license in text, e.g. "a port of an MIT license library", doesn't match.
+var DefaultKeywordRules = []KeywordRule{
+	{LicenseID: "MIT", Pattern: regexp.MustCompile(`(?m)^\W*(?:the )?mit license\b`)},
+	{LicenseID: "Apache-2.0", Pattern: regexp.MustCompile(`(?m)^\W*apache license\b(?:.|\n)*\bversion 2\.0\b`)},
+	{LicenseID: "MPL-2.0", Pattern: regexp.MustCompile(`(?m)^\W*mozilla public license\b(?:.|\n)*\bversion 2\.0\b`)},
+}
+
+// KeywordDetector recognizes license by keyword rules of its title. Keywords are weak evidence, so confidence of
+// its results is MinimumConfidence.
+type KeywordDetector struct {
+	rules []KeywordRule
+}
+
+// NewKeywordDetector creates keyword detector with rules, the first matched rule wins
+func NewKeywordDetector(rules ...KeywordRule) KeywordDetector {
+	return KeywordDetector{rules: append([]KeywordRule{}, rules...)}
+}
+
+func (KeywordDetector) Name() string {
+	return DetectorKeyword
+}
+
+func (d KeywordDetector) Detect(content []byte) (DetectResult, error) {
+	c, err := loadCorpus()
+	if err != nil {
+		return DetectResult{}, err
+	}
+	title := strings.ToLower(titleLines(format.Strip(content, format.Unknown).Text, keywordTitleLines))
+	for _, rule := range d.rules {
+		if !rule.Pattern.MatchString(title) {
+			continue
+		}
+		if info, ok := c.find(rule.LicenseID); ok {
+			result := c.mentionedLicense(info, title)
+			result.Confidence = MinimumConfidence
+			return result, nil
+		}
+	}
+	return DetectResult{}, ErrorNoMatch
+}
+
+// FuzzyDetector compares content with all licenses by their words, see DetectWithOptions
+type FuzzyDetector struct {
+	Options DetectOptions
+}
+
+func (FuzzyDetector) Name() string {
+	return DetectorFuzzy
+}
+
+func (d FuzzyDetector) Detect(content []byte) (DetectResult, error) {
+	return DetectWithOptions(content, d.Options)
+}
+
+// URLDetector recognizes license by the first URL in content that is its reference, e.g. "Licensed under
+// http://www.apache.org/licenses/LICENSE-2.0". A URL is weak evidence, so confidence of its results is MinimumConfidence.
+type URLDetector struct{}
+
+func (URLDetector) Name() string {
+	return DetectorURL
+}
+
+func (URLDetector) Detect(content []byte) (DetectResult, error) {
+	c, err := loadCorpus()
+	if err != nil {
+		return DetectResult{}, err
+	}
+	references := c.findReferencedURLs(content)
+	if len(references) == 0 {
+		return DetectResult{}, ErrorNoMatch
+	}
+	// The line of URL tells scope of a GNU license, e.g. "GPL-2.0 or later, see https://www.gnu.org/licenses/..."
+	start := strings.LastIndexByte(string(content[:references[0].Start]), '\n') + 1
+	end := references[0].End + strings.IndexByte(string(content[references[0].End:])+"\n", '\n')
+	result := c.mentionedLicense(references[0].Licenses[0], string(content[start:end]))
+	result.Confidence = MinimumConfidence
+	result.ReferencedURLs = references
+	return result, nil
+}
+
+// titleLines returns the first count non-empty lines of text
+func titleLines(text string, count int) string {
+	lines := []string{}
+	for _, line := range strings.Split(text, "\n") {
+		if len(lines) == count {
+			break
+		}
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
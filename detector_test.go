@@ -0,0 +1,145 @@
+package licensechecker
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ledongthuc/licensechecker/internal/data"
+)
+
+// staticDetector is a user detector that always returns the same license
+type staticDetector struct {
+	name      string
+	licenseID string
+}
+
+func (d staticDetector) Name() string {
+	return d.name
+}
+
+func (d staticDetector) Detect(content []byte) (DetectResult, error) {
+	if d.licenseID == "" {
+		return DetectResult{}, ErrorNoMatch
+	}
+	return DetectResult{Candidate: Candidate{LicenseInfo: LicenseInfo{LicenseID: d.licenseID}}}, nil
+}
+
+func TestDefaultChain_Detect(t *testing.T) {
+	contentMIT, _ := data.Asset("MIT.txt")
+	contentApache, _ := data.Asset("Apache-2.0.txt")
+	contentLLVM, _ := data.Asset("LLVM-exception.txt")
+	tests := []struct {
+		name      string
+		content   string
+		licenseID string
+		exception string
+		detector  string
+		wantErr   error
+	}{
+		{
+			name:      "Verbatim license text",
+			content:   string(contentMIT),
+			licenseID: "MIT",
+			detector:  DetectorExact,
+		},
+		{
+			name:      "SPDX identifier tag",
+			content:   "// SPDX-License-Identifier: Apache-2.0\npackage main\n",
+			licenseID: "Apache-2.0",
+			detector:  DetectorSPDXTag,
+		},
+		{
+			name:      "License title",
+			content:   "Apache License\nVersion 2.0, January 2004\n\nParts of this license are left out.",
+			licenseID: "Apache-2.0",
+			detector:  DetectorKeyword,
+		},
+		{
+			name:      "Modified license text without title",
+			content:   strings.TrimPrefix(exampleMITContent, "MIT License") + "\nThe Software shall be used for good, not evil.",
+			licenseID: "MIT",
+			detector:  DetectorFuzzy,
+		},
+		{
+			name:      "License text with title and exception",
+			content:   string(contentApache) + "\n\n" + string(contentLLVM),
+			licenseID: "Apache-2.0",
+			exception: "LLVM-exception",
+			detector:  DetectorFuzzy,
+		},
+		{
+			name:      "License URL",
+			content:   "# Example\n\nLicensed under http://www.apache.org/licenses/LICENSE-2.0.",
+			licenseID: "Apache-2.0",
+			detector:  DetectorURL,
+		},
+		{
+			name:    "Other license is mentioned",
+			content: "# Example\n\nA port of an MIT license library. This project is licensed under the GNU General Public License v3.0.",
+			wantErr: ErrorNoMatch,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DefaultChain().Detect([]byte(tt.content))
+			if err != tt.wantErr {
+				t.Errorf("Detect() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got.LicenseID != tt.licenseID || got.Exception.LicenseID != tt.exception || got.Detector != tt.detector {
+				t.Errorf("Detect() = %v with %v by %v, want %v with %v by %v", got.LicenseID, got.Exception.LicenseID,
+					got.Detector, tt.licenseID, tt.exception, tt.detector)
+			}
+		})
+	}
+}
+
+func TestChain_configure(t *testing.T) {
+	chain := DefaultChain()
+	chain.Register(staticDetector{name: "custom", licenseID: "Custom-1.0"})
+	if !chain.Remove(DetectorKeyword) || chain.Remove("missing") {
+		t.Errorf("Remove() doesn't remove only known detectors")
+	}
+	if want := []string{DetectorExact, DetectorSPDXTag, DetectorFuzzy, DetectorURL, "custom"}; !reflect.DeepEqual(chain.Names(), want) {
+		t.Errorf("Names() = %v, want %v", chain.Names(), want)
+	}
+
+	if err := chain.Reorder("custom", DetectorFuzzy); err != nil {
+		t.Errorf("Reorder() error = %v", err)
+	}
+	if err := chain.Reorder("missing"); err == nil {
+		t.Errorf("Reorder() of unknown detector doesn't return error")
+	}
+	got, err := chain.Detect([]byte(exampleMITContent))
+	if err != nil || got.LicenseID != "Custom-1.0" || got.Detector != "custom" {
+		t.Errorf("Detect() = %v by %v, error = %v, want Custom-1.0 by custom", got.LicenseID, got.Detector, err)
+	}
+
+	chain = NewChain(staticDetector{name: "nothing"})
+	if _, err := chain.Detect([]byte(exampleMITContent)); err != ErrorNoMatch {
+		t.Errorf("Detect() error = %v, want %v", err, ErrorNoMatch)
+	}
+}
+
+func TestGetLicenseName(t *testing.T) {
+	contentApache, _ := data.Asset("Apache-2.0.txt")
+	contentLLVM, _ := data.Asset("LLVM-exception.txt")
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{name: "License text", content: exampleMITContent, want: "MIT License"},
+		{name: "Expression tag", content: "# SPDX-License-Identifier: MIT OR Apache-2.0\n", want: "MIT OR Apache-2.0"},
+		{name: "License text with exception", content: string(contentApache) + "\n\n" + string(contentLLVM), want: "Apache License 2.0 with LLVM Exception"},
+		{name: "Mention of license", content: "A port of an MIT license library.", want: UnknownLicenseName},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetLicenseName(tt.content); got != tt.want {
+				t.Errorf("GetLicenseName() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
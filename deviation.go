@@ -0,0 +1,150 @@
+package licensechecker
+
+import (
+	"strings"
+	"unicode/utf8"
+
+	"github.com/ledongthuc/licensechecker/normalize"
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+// maximumTitleWords is the highest number of license words missing before the license text that are still considered as its title
+const maximumTitleWords = 10
+
+// fillerWords don't change meaning of a license when they're added, removed or replaced
+var fillerWords = map[string]struct{}{
+	"a":     {},
+	"an":    {},
+	"the":   {},
+	"this":  {},
+	"that":  {},
+	"these": {},
+	"those": {},
+}
+
+// DeviationType defines kinds of difference between inputted content and license text
+type DeviationType int
+
+const (
+	// DeviationInserted is words of content that aren't in license text
+	DeviationInserted DeviationType = iota
+	// DeviationDeleted is words of license text that are missing in content
+	DeviationDeleted
+	// DeviationSubstituted is words of license text that are replaced by other words in content
+	DeviationSubstituted
+)
+
+// Deviation is a word-level difference between inputted content and the matched license text
+type Deviation struct {
+	Type DeviationType
+	// Content and License are normalized words of both sides, one of them is empty for inserted or deleted words
+	Content string
+	License string
+	// Start and End are byte range [Start, End) of content, they're the same position for deleted words
+	Start int
+	End   int
+	// Substantive is true if the deviation can change meaning of license, e.g. an added clause or a removed section
+	Substantive bool
+}
+
+// findDeviations converts word alignment into deviations. Content outside of the license region [first, last) is
+// surrounding text instead of deviation, so it's skipped.
+func findDeviations(tokens []normalize.Word, license []string, diffs []diffmatchpatch.Diff, first, last int) []Deviation {
+	result := []Deviation{}
+	contentPosition, licensePosition := 0, 0
+	contentStart, licenseStart := 0, 0
+	matchedAny := false
+
+	flush := func() {
+		// Inserted words outside of license region are trimmed
+		from, to := contentStart, contentPosition
+		if from < first {
+			from = first
+		}
+		if to > last {
+			to = last
+		}
+		if to < from {
+			to = from
+		}
+		if from == to && licenseStart == licensePosition {
+			return
+		}
+
+		deviation := Deviation{
+			Content: joinTokens(tokens[from:to]),
+			License: strings.Join(license[licenseStart:licensePosition], " "),
+		}
+		switch {
+		case from == to:
+			deviation.Type = DeviationDeleted
+			deviation.Start = tokenOffset(tokens, from)
+			deviation.End = deviation.Start
+		case licenseStart == licensePosition:
+			deviation.Type = DeviationInserted
+			deviation.Start, deviation.End = tokens[from].Start, tokens[to-1].End
+		default:
+			deviation.Type = DeviationSubstituted
+			deviation.Start, deviation.End = tokens[from].Start, tokens[to-1].End
+		}
+		isTitle := !matchedAny && deviation.Type == DeviationDeleted && licensePosition-licenseStart <= maximumTitleWords
+		deviation.Substantive = !isTitle && !(onlyFillerWords(deviation.Content) && onlyFillerWords(deviation.License))
+		result = append(result, deviation)
+	}
+
+	for _, diff := range diffs {
+		length := utf8.RuneCountInString(diff.Text)
+		switch diff.Type {
+		case diffmatchpatch.DiffEqual:
+			flush()
+			matchedAny = true
+			contentPosition += length
+			licensePosition += length
+			contentStart, licenseStart = contentPosition, licensePosition
+		case diffmatchpatch.DiffDelete:
+			contentPosition += length
+		case diffmatchpatch.DiffInsert:
+			licensePosition += length
+		}
+	}
+	flush()
+	return result
+}
+
+// tokenOffset is byte offset of content before the token at position
+func tokenOffset(tokens []normalize.Word, position int) int {
+	if position < len(tokens) {
+		return tokens[position].Start
+	}
+	if len(tokens) > 0 {
+		return tokens[len(tokens)-1].End
+	}
+	return 0
+}
+
+func joinTokens(tokens []normalize.Word) string {
+	words := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		words = append(words, token.Text)
+	}
+	return strings.Join(words, " ")
+}
+
+func onlyFillerWords(text string) bool {
+	for _, word := range strings.Fields(text) {
+		if _, ok := fillerWords[word]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// isModified checks whether any deviation is substantive
+func isModified(deviations []Deviation) bool {
+	for _, deviation := range deviations {
+		if deviation.Substantive {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,165 @@
+package licensechecker
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ledongthuc/licensechecker/internal/data"
+	"github.com/ledongthuc/licensechecker/normalize"
+)
+
+func TestDetectWithOptions_deviations(t *testing.T) {
+	contentApache, _ := data.Asset("Apache-2.0.txt")
+	patentSection := textSection(string(contentApache), "3. Grant of Patent License.", "4. Redistribution.")
+	retainNoticesClause := textSection(string(contentApache), "(c) You must retain", "(d) If the Work")
+
+	tests := []struct {
+		name      string
+		content   string
+		modified  bool
+		deviation string
+	}{
+		{
+			name:     "Same license",
+			content:  exampleMITContent,
+			modified: false,
+		},
+		{
+			name:     "Cosmetic differences",
+			content:  exampleCosmeticMITContent,
+			modified: false,
+		},
+		{
+			name:     "Re-wrapped line starts with copyright notice",
+			content:  strings.Replace(exampleMITContent, "The above copyright notice", "The above\ncopyright notice", 1),
+			modified: false,
+		},
+		{
+			name: "Extra clause",
+			content: strings.Replace(exampleMITContent, "copies or substantial portions of the Software.",
+				"copies or substantial portions of the Software.\n\nThe name of the author may not be used in advertising.", 1),
+			modified:  true,
+			deviation: "name of the author may not be used in advertising",
+		},
+		{
+			name: "Long inserted clause",
+			content: strings.Replace(exampleMITContent, "The above copyright notice",
+				"Neither the name of the copyright holder nor the names of its contributors may be used in any advertising or publicity without specific prior written permission.\n\nThe above copyright notice", 1),
+			modified:  true,
+			deviation: "advertising or publicity without specific prior written permission",
+		},
+		{
+			name: "Appended rider",
+			content: exampleMITContent + `
+"Commons Clause" License Condition v1.0
+
+The Software is provided to you by the Licensor under the License, as defined below, subject to the following condition.
+
+Without limiting other conditions in the License, the grant of rights under the License will not include, and the License does not grant to you, the right to Sell the Software.
+
+For purposes of the foregoing, "Sell" means practicing any or all of the rights granted to you under the License to provide to third parties, for a fee or other consideration (including without limitation fees for hosting or consulting/ support services related to the Software), a product or service whose value derives, entirely or substantially, from the functionality of the Software. Any license notice or attribution required by the License must also include this Commons Clause License Condition notice.
+`,
+			modified:  true,
+			deviation: "the right to sell the software",
+		},
+		{
+			name:      "Removed section",
+			content:   strings.Replace(string(contentApache), patentSection, "", 1),
+			modified:  true,
+			deviation: "grant of patent license",
+		},
+		{
+			name:      "Removed list item (c)",
+			content:   strings.Replace(string(contentApache), retainNoticesClause, "", 1),
+			modified:  true,
+			deviation: "you must retain",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DetectWithOptions([]byte(tt.content), DetectOptions{})
+			if err != nil {
+				t.Errorf("DetectWithOptions() error = %v", err)
+				return
+			}
+			if got.Modified != tt.modified {
+				t.Errorf("DetectWithOptions() modified = %v, want %v, deviations %+v", got.Modified, tt.modified, got.Deviations)
+			}
+			if tt.deviation == "" {
+				return
+			}
+			found := false
+			for _, deviation := range got.Deviations {
+				if deviation.Substantive && (strings.Contains(deviation.Content, tt.deviation) || strings.Contains(deviation.License, tt.deviation)) {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("DetectWithOptions() deviations = %+v, want one contains %q", got.Deviations, tt.deviation)
+			}
+		})
+	}
+}
+
+// textSection returns text from start marker until end marker
+func textSection(text, start, end string) string {
+	from := strings.Index(text, start)
+	to := strings.Index(text, end)
+	if from < 0 || to < from {
+		return ""
+	}
+	return text[from:to]
+}
+
+func Test_findDeviations(t *testing.T) {
+	license := strings.Fields("mit license permission is granted to use the software the software is provided as is")
+	tests := []struct {
+		name    string
+		content string
+		want    []Deviation
+	}{
+		{
+			name:    "Missing title and surrounding text",
+			content: "Header. Permission is granted to use the software. The software is provided as is. Footer.",
+			want: []Deviation{
+				{Type: DeviationDeleted, License: "mit license", Start: 8, End: 8},
+			},
+		},
+		{
+			name:    "Inserted word",
+			content: "MIT License. Permission is not granted to use the software. The software is provided as is.",
+			want: []Deviation{
+				{Type: DeviationInserted, Content: "not", Start: 27, End: 30, Substantive: true},
+			},
+		},
+		{
+			name:    "Substituted filler word",
+			content: "MIT License. Permission is granted to use this software. The software is provided as is.",
+			want: []Deviation{
+				{Type: DeviationSubstituted, Content: "this", License: "the", Start: 42, End: 46},
+			},
+		},
+		{
+			name:    "Deleted words",
+			content: "MIT License. Permission is granted to use the software. The software is provided.",
+			want: []Deviation{
+				{Type: DeviationDeleted, License: "as is", Start: 80, End: 80, Substantive: true},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tokens := normalize.Tokenize(tt.content)
+			words := make([]string, 0, len(tokens))
+			for _, token := range tokens {
+				words = append(words, token.Text)
+			}
+			region := findRegion(words, corpusEntry{words: license})
+			got := findDeviations(tokens, license, region.diffs, region.first, region.last)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("findDeviations() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,50 @@
+package licensechecker
+
+import (
+	"github.com/ledongthuc/licensechecker/internal/fingerprints"
+	"github.com/ledongthuc/licensechecker/normalize"
+)
+
+// findVerbatim looks up normalized words of content in the fingerprint table, which is generated with license data,
+// see newFingerprintTable, so a verbatim copy of license text is found in time of hashing it. Verbatim text doesn't have a notice, so scope of
+// GNU licenses is ambiguous.
+func (c corpus) findVerbatim(words []string) (DetectResult, error) {
+	if len(words) == 0 {
+		return DetectResult{}, ErrorNoMatch
+	}
+	info, ok := c.find(c.fingerprints[normalize.Fingerprint(words)])
+	if !ok {
+		return DetectResult{}, ErrorNoMatch
+	}
+
+	license, exception := c.resolveDeprecated(info, LicenseInfo{})
+	license, scope := c.withScope(license, ScopeAmbiguous)
+	return DetectResult{
+		Candidate:  Candidate{LicenseInfo: license, Confidence: 1, Coverage: 1},
+		Exception:  exception,
+		Expression: licenseExpression(license, exception),
+		Scope:      scope,
+		Deviations: []Deviation{},
+	}, nil
+}
+
+// newFingerprintTable returns the generated fingerprint table. Until it's generated, fingerprints of entries are hashed
+// when corpus is loaded, and a current license wins over a deprecated one with the same text like in the generated table.
+func newFingerprintTable(entries []corpusEntry) map[string]string {
+	if len(fingerprints.Fingerprints) > 0 {
+		return fingerprints.Fingerprints
+	}
+	owners := make(map[string]int, len(entries))
+	for index, entry := range entries {
+		key := normalize.Fingerprint(entry.words)
+		if owner, ok := owners[key]; ok && (!entries[owner].info.IsDeprecated || entry.info.IsDeprecated) {
+			continue
+		}
+		owners[key] = index
+	}
+	table := make(map[string]string, len(owners))
+	for key, index := range owners {
+		table[key] = entries[index].info.LicenseID
+	}
+	return table
+}
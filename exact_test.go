@@ -0,0 +1,85 @@
+package licensechecker
+
+import (
+	"testing"
+
+	"github.com/ledongthuc/licensechecker/internal/data"
+	"github.com/ledongthuc/licensechecker/internal/fingerprints"
+	"github.com/ledongthuc/licensechecker/normalize"
+)
+
+func TestFingerprintsAreCurrent(t *testing.T) {
+	if len(fingerprints.Fingerprints) == 0 {
+		t.Skip("fingerprint table isn't generated, it's generated by make update")
+	}
+	c, err := loadCorpus()
+	if err != nil {
+		t.Errorf("loadCorpus() error = %v", err)
+		return
+	}
+	keys := make(map[string]string, len(c.entries))
+	for _, entry := range c.entries {
+		keys[entry.info.LicenseID] = normalize.Fingerprint(entry.words)
+	}
+	for licenseID, key := range keys {
+		// Licenses that have the same text share a fingerprint
+		if owner, ok := fingerprints.Fingerprints[key]; !ok || keys[owner] != key {
+			t.Errorf("fingerprint of %s isn't in the table, it's generated by make update", licenseID)
+		}
+	}
+	for key, licenseID := range fingerprints.Fingerprints {
+		if current, ok := keys[licenseID]; !ok || current != key {
+			t.Errorf("fingerprint of %s in the table isn't of the bundled text, it's generated by make update", licenseID)
+		}
+	}
+}
+
+func TestDetect_verbatim(t *testing.T) {
+	contentGPL, _ := data.Asset("GPL-3.0-only.txt")
+	tests := []struct {
+		name       string
+		content    string
+		options    DetectOptions
+		licenseID  string
+		variables  map[string]string
+		candidates bool
+	}{
+		{
+			name:      "Verbatim license with copyright",
+			content:   exampleMITContent,
+			licenseID: "MIT",
+			variables: map[string]string{"copyright": "Copyright (c) 2019 Thuc Le"},
+		},
+		{
+			name:      "Verbatim GNU license",
+			content:   string(contentGPL),
+			licenseID: "GPL-3.0-only",
+		},
+		{
+			name:       "Alternatives need fuzzy matching",
+			content:    exampleMITContent,
+			options:    DetectOptions{NumberOfCandidates: 2},
+			licenseID:  "MIT",
+			variables:  map[string]string{"copyright": "Copyright (c) 2019 Thuc Le"},
+			candidates: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DetectWithOptions([]byte(tt.content), tt.options)
+			if err != nil {
+				t.Errorf("DetectWithOptions() error = %v", err)
+				return
+			}
+			if got.LicenseID != tt.licenseID || got.Confidence != 1 || got.Modified {
+				t.Errorf("DetectWithOptions() = %v, confidence %v, modified %v, want %v", got.LicenseID, got.Confidence, got.Modified, tt.licenseID)
+			}
+			if tt.variables != nil && got.Variables["copyright"] != tt.variables["copyright"] {
+				t.Errorf("DetectWithOptions() variables = %v, want %v", got.Variables, tt.variables)
+			}
+			if (len(got.Candidates) > 0) != tt.candidates {
+				t.Errorf("DetectWithOptions() candidates = %v, want candidates %v", got.Candidates, tt.candidates)
+			}
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package licensechecker
+
+// findException finds the best license exception in inputted words, e.g. the words after a license text
+func (c corpus) findException(words []string) (regionCandidate, bool) {
+	best := regionCandidate{}
+	found := false
+	for _, item := range c.index.containing(words, numberOfRegionCandidates) {
+		entry := c.entries[item.index]
+		if !entry.exception {
+			continue
+		}
+		candidate := regionOfAlignment(entry, alignWords(words, entry.words, item.first, item.last))
+		if candidate.confidence < MinimumConfidence {
+			continue
+		}
+		if !found || candidate.better(best) {
+			best = candidate
+			found = true
+		}
+	}
+	return best, found
+}
+
+// isFollowedByException checks whether next region is an exception that right follows a license region,
+// so both of them are a license with exception, instead of two separated licenses
+func isFollowedByException(region, next regionCandidate) bool {
+	return !region.entry.exception && next.entry.exception && next.first-region.last <= minimumRegionGap
+}
+
+// licenseExpression composes SPDX license expression of a license and its exception. Exception is skipped if its LicenseID is empty.
+func licenseExpression(license, exception LicenseInfo) string {
+	if exception.LicenseID == "" {
+		return license.LicenseID
+	}
+	return license.LicenseID + " WITH " + exception.LicenseID
+}
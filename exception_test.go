@@ -0,0 +1,108 @@
+package licensechecker
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ledongthuc/licensechecker/internal/data"
+)
+
+func TestDetectWithOptions_exception(t *testing.T) {
+	contentGPL, _ := data.Asset("GPL-2.0-only.txt")
+	contentClasspath, _ := data.Asset("Classpath-exception-2.0.txt")
+	contentApache, _ := data.Asset("Apache-2.0.txt")
+	contentLLVM, _ := data.Asset("LLVM-exception.txt")
+
+	tests := []struct {
+		name        string
+		content     string
+		exceptionID string
+		expression  string
+	}{
+		{
+			name:       "License without exception",
+			content:    exampleMITContent,
+			expression: "MIT",
+		},
+		{
+			name:        "GPL with Classpath exception",
+			content:     string(contentGPL) + "\n\n" + string(contentClasspath),
+			exceptionID: "Classpath-exception-2.0",
+			expression:  "GPL-2.0-only WITH Classpath-exception-2.0",
+		},
+		{
+			name:        "Apache with LLVM exception",
+			content:     string(contentApache) + "\n\n" + string(contentLLVM),
+			exceptionID: "LLVM-exception",
+			expression:  "Apache-2.0 WITH LLVM-exception",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DetectWithOptions([]byte(tt.content), DetectOptions{})
+			if err != nil {
+				t.Errorf("DetectWithOptions() error = %v", err)
+				return
+			}
+			if got.Exception.LicenseID != tt.exceptionID {
+				t.Errorf("DetectWithOptions() exception = %v, want %v", got.Exception.LicenseID, tt.exceptionID)
+			}
+			if got.Expression != tt.expression {
+				t.Errorf("DetectWithOptions() expression = %v, want %v", got.Expression, tt.expression)
+			}
+		})
+	}
+}
+
+func TestDetectRegions_exception(t *testing.T) {
+	contentGPL, _ := data.Asset("GPL-2.0-only.txt")
+	contentClasspath, _ := data.Asset("Classpath-exception-2.0.txt")
+	content := exampleMITContent + "\n\n----------\n\n" + string(contentGPL) + "\n\n" + string(contentClasspath)
+
+	got, err := DetectRegions([]byte(content))
+	if err != nil {
+		t.Errorf("DetectRegions() error = %v", err)
+		return
+	}
+	exceptionIDs := []string{}
+	for _, region := range got.Regions {
+		exceptionIDs = append(exceptionIDs, region.Exception.LicenseID)
+	}
+	want := []string{"", "Classpath-exception-2.0"}
+	if !reflect.DeepEqual(exceptionIDs, want) {
+		t.Errorf("DetectRegions() exceptions = %v, want %v", exceptionIDs, want)
+		return
+	}
+	if end, want := got.Regions[1].End, len(strings.TrimSpace(content)); end != want {
+		t.Errorf("DetectRegions() end of license with exception = %v, want %v", end, want)
+	}
+}
+
+func Test_licenseExpression(t *testing.T) {
+	tests := []struct {
+		name      string
+		license   LicenseInfo
+		exception LicenseInfo
+		want      string
+	}{
+		{
+			name:    "License only",
+			license: LicenseInfo{LicenseID: "MIT"},
+			want:    "MIT",
+		},
+		{
+			name:      "License with exception",
+			license:   LicenseInfo{LicenseID: "GPL-2.0-or-later"},
+			exception: LicenseInfo{LicenseID: "Classpath-exception-2.0"},
+			want:      "GPL-2.0-or-later WITH Classpath-exception-2.0",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := licenseExpression(tt.license, tt.exception); got != tt.want {
+				t.Errorf("licenseExpression() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,322 @@
+package licensechecker
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	"github.com/ledongthuc/licensechecker/format"
+	"github.com/ledongthuc/licensechecker/normalize"
+	"github.com/pkg/errors"
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+const (
+	// minimumExplainedCandidates is the lowest number of candidates that are explained, so distinguishing clauses
+	// of the best match are compared with its closest competitors
+	minimumExplainedCandidates = 5
+	// maximumKeyPhrases is the highest number of matched phrases that are listed for a candidate
+	maximumKeyPhrases = 5
+	// minimumClauseWords is the lowest number of words of a clause, shorter sentences are titles and list numbers
+	minimumClauseWords = 4
+	// clauseFoundRatio is the lowest fraction of clause words that content must match to have the clause
+	clauseFoundRatio = 0.8
+	// competitorMargin is the highest confidence difference between the best candidate and its close competitors
+	competitorMargin = 0.1
+	// maximumRenderedText is the highest number of characters of a phrase or clause in text rendering
+	maximumRenderedText = 80
+)
+
+// clauseBoundaryPattern matches ends of sentences and paragraphs that break license text into clauses
+var clauseBoundaryPattern = regexp.MustCompile(`[.;:!?](?:\s|$)|\n[ \t]*\n`)
+
+// Explanation is evidence trail of a detection. It tells how content was normalized, and why the best candidate is
+// ranked over the others.
+type Explanation struct {
+	// Format is markup language of content that is stripped before normalization
+	Format string `json:"format"`
+	// Steps are normalization steps that are applied to content and license texts
+	Steps      []string            `json:"normalizationSteps"`
+	Candidates []CandidateEvidence `json:"candidates"`
+}
+
+// CandidateEvidence explains score of a candidate
+type CandidateEvidence struct {
+	LicenseID  string  `json:"licenseId"`
+	Confidence float64 `json:"confidence"`
+	Coverage   float64 `json:"coverage"`
+	// KeyPhrases are the longest runs of license words that content matches
+	KeyPhrases []Phrase `json:"keyPhrases"`
+	// FoundClauses and MissingClauses are distinguishing clauses of the license, which content has or doesn't have.
+	// Clauses of the best candidate distinguish it from close competitors, and clauses of the others distinguish them
+	// from the best candidate.
+	FoundClauses   []string `json:"foundClauses"`
+	MissingClauses []string `json:"missingClauses"`
+}
+
+// Report is summary of a detection with its explanation, see DetectResult.JSON
+type Report struct {
+	LicenseID  string  `json:"licenseId"`
+	Name       string  `json:"name"`
+	Expression string  `json:"expression"`
+	Exception  string  `json:"exception"`
+	Scope      string  `json:"scope"`
+	Confidence float64 `json:"confidence"`
+	// Explanation is nil unless DetectOptions.Explain is set
+	Explanation *Explanation `json:"explanation"`
+}
+
+// Phrase is a matched text at byte range [Start, End) of content
+type Phrase struct {
+	Text  string `json:"text"`
+	Start int    `json:"start"`
+	End   int    `json:"end"`
+}
+
+// clause is a sentence of license text at range [first, last) of license words
+type clause struct {
+	text        string
+	first, last int
+}
+
+// clauseCache keeps clauses of a corpus entry, they're broken once
+type clauseCache struct {
+	once    sync.Once
+	clauses []clause
+}
+
+// explain builds evidence of ranked candidates, the best one goes first. Regions keep word alignments of candidates.
+func explain(content []byte, contentFormat format.Format, text format.Text, tokens []normalize.Word, ranked []Candidate, regions map[string]regionCandidate) *Explanation {
+	explanation := &Explanation{
+		Format:     contentFormat.String(),
+		Steps:      normalize.AllSteps.Names(),
+		Candidates: make([]CandidateEvidence, 0, len(ranked)),
+	}
+
+	texts := make([]string, len(ranked))
+	for index, candidate := range ranked {
+		texts[index] = " " + strings.Join(regions[candidate.LicenseID].entry.words, " ") + " "
+	}
+	for index, candidate := range ranked {
+		region := regions[candidate.LicenseID]
+		evidence := CandidateEvidence{
+			LicenseID:      candidate.LicenseID,
+			Confidence:     candidate.Confidence,
+			Coverage:       candidate.Coverage,
+			KeyPhrases:     keyPhrases(content, text, tokens, region.diffs),
+			FoundClauses:   []string{},
+			MissingClauses: []string{},
+		}
+
+		matched := matchedLicenseWords(region.diffs, len(region.entry.words))
+		for _, c := range region.entry.licenseClauses() {
+			if !isDistinguishing(" "+strings.Join(region.entry.words[c.first:c.last], " ")+" ", ranked, texts, index) {
+				continue
+			}
+			count := 0
+			for position := c.first; position < c.last; position++ {
+				if matched[position] {
+					count++
+				}
+			}
+			if float64(count) >= clauseFoundRatio*float64(c.last-c.first) {
+				evidence.FoundClauses = append(evidence.FoundClauses, c.text)
+			} else {
+				evidence.MissingClauses = append(evidence.MissingClauses, c.text)
+			}
+		}
+		explanation.Candidates = append(explanation.Candidates, evidence)
+	}
+	return explanation
+}
+
+// keyPhrases are the longest runs of matched words by their position in content
+func keyPhrases(content []byte, text format.Text, tokens []normalize.Word, diffs []diffmatchpatch.Diff) []Phrase {
+	type run struct {
+		start, length int
+	}
+	runs := []run{}
+	position := 0
+	for _, diff := range diffs {
+		length := utf8.RuneCountInString(diff.Text)
+		switch diff.Type {
+		case diffmatchpatch.DiffEqual:
+			runs = append(runs, run{start: position, length: length})
+			position += length
+		case diffmatchpatch.DiffDelete:
+			position += length
+		}
+	}
+	sort.SliceStable(runs, func(i, j int) bool {
+		return runs[i].length > runs[j].length
+	})
+	if len(runs) > maximumKeyPhrases {
+		runs = runs[:maximumKeyPhrases]
+	}
+	sort.Slice(runs, func(i, j int) bool {
+		return runs[i].start < runs[j].start
+	})
+
+	phrases := make([]Phrase, 0, len(runs))
+	for _, r := range runs {
+		start, end := text.Span(tokens[r.start].Start, tokens[r.start+r.length-1].End)
+		phrases = append(phrases, Phrase{
+			Text:  strings.Join(strings.Fields(string(content[start:end])), " "),
+			Start: start,
+			End:   end,
+		})
+	}
+	return phrases
+}
+
+// matchedLicenseWords marks license words that are matched by content
+func matchedLicenseWords(diffs []diffmatchpatch.Diff, length int) []bool {
+	matched := make([]bool, length)
+	position := 0
+	for _, diff := range diffs {
+		count := utf8.RuneCountInString(diff.Text)
+		switch diff.Type {
+		case diffmatchpatch.DiffEqual:
+			for index := position; index < position+count && index < length; index++ {
+				matched[index] = true
+			}
+			position += count
+		case diffmatchpatch.DiffInsert:
+			position += count
+		}
+	}
+	return matched
+}
+
+// licenseClauses returns clauses of entry, they're cached unless entry is created outside of corpus
+func (e corpusEntry) licenseClauses() []clause {
+	if e.clauses == nil {
+		return licenseClauses(e.info, e.words)
+	}
+	e.clauses.once.Do(func() {
+		e.clauses.clauses = licenseClauses(e.info, e.words)
+	})
+	return e.clauses.clauses
+}
+
+// licenseClauses breaks license text into sentences and paragraphs, and keeps their ranges of license words.
+// Clauses are empty if the license text can't be loaded, or it doesn't have the same words.
+func licenseClauses(info LicenseInfo, words []string) []clause {
+	content, err := info.LoadLicenseContent()
+	if err != nil {
+		return nil
+	}
+	licenseText := string(content.Content)
+	tokens := normalize.Tokenize(licenseText)
+	if len(tokens) != len(words) {
+		return nil
+	}
+
+	clauses := []clause{}
+	add := func(first, last int) {
+		if last-first < minimumClauseWords {
+			return
+		}
+		clauses = append(clauses, clause{
+			text:  strings.Join(strings.Fields(licenseText[tokens[first].Start:tokens[last-1].End]), " "),
+			first: first,
+			last:  last,
+		})
+	}
+	boundaries := clauseBoundaryPattern.FindAllStringIndex(licenseText, -1)
+	first, boundary := 0, 0
+	for index, token := range tokens {
+		for boundary < len(boundaries) && boundaries[boundary][1] <= token.Start {
+			if index > first {
+				add(first, index)
+				first = index
+			}
+			boundary++
+		}
+	}
+	add(first, len(tokens))
+	return clauses
+}
+
+// isDistinguishing checks whether a clause of the candidate at index is missing in texts of candidates that it's
+// compared with. The best candidate is compared with close competitors, the others are compared with the best one.
+func isDistinguishing(clauseText string, ranked []Candidate, texts []string, index int) bool {
+	if index > 0 {
+		return !strings.Contains(texts[0], clauseText)
+	}
+	for other := 1; other < len(ranked); other++ {
+		if ranked[0].Confidence-ranked[other].Confidence <= competitorMargin && !strings.Contains(texts[other], clauseText) {
+			return true
+		}
+	}
+	return false
+}
+
+// Text renders explanation for people, e.g. output of command line
+func (e Explanation) Text() string {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "Format: %s\n", e.Format)
+	fmt.Fprintf(&builder, "Normalization: %s\n", strings.Join(e.Steps, ", "))
+	for index, candidate := range e.Candidates {
+		fmt.Fprintf(&builder, "\n%d. %s (confidence %.3f, coverage %.3f)\n", index+1, candidate.LicenseID, candidate.Confidence, candidate.Coverage)
+		if len(candidate.KeyPhrases) > 0 {
+			builder.WriteString("   Key phrases:\n")
+			for _, phrase := range candidate.KeyPhrases {
+				fmt.Fprintf(&builder, "     [%d, %d) %q\n", phrase.Start, phrase.End, shortenText(phrase.Text))
+			}
+		}
+		if len(candidate.FoundClauses) > 0 {
+			builder.WriteString("   Distinguishing clauses found:\n")
+			for _, c := range candidate.FoundClauses {
+				fmt.Fprintf(&builder, "     + %s\n", shortenText(c))
+			}
+		}
+		if len(candidate.MissingClauses) > 0 {
+			builder.WriteString("   Distinguishing clauses missing:\n")
+			for _, c := range candidate.MissingClauses {
+				fmt.Fprintf(&builder, "     - %s\n", shortenText(c))
+			}
+		}
+	}
+	return builder.String()
+}
+
+// JSON renders explanation as indented JSON to archive it
+func (e Explanation) JSON() ([]byte, error) {
+	content, err := json.MarshalIndent(e, "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(err, "Error when encode explanation into JSON")
+	}
+	return content, nil
+}
+
+// JSON renders summary of result and its explanation as indented JSON to archive it, see Report
+func (r DetectResult) JSON() ([]byte, error) {
+	report := Report{
+		LicenseID:   r.LicenseID,
+		Name:        r.Name,
+		Expression:  r.Expression,
+		Exception:   r.Exception.LicenseID,
+		Scope:       r.Scope.String(),
+		Confidence:  r.Confidence,
+		Explanation: r.Explanation,
+	}
+	content, err := json.MarshalIndent(report, "", "  ")
+	if err != nil {
+		return nil, errors.Wrap(err, "Error when encode detection into JSON")
+	}
+	return content, nil
+}
+
+// shortenText cuts long text at maximumRenderedText characters
+func shortenText(text string) string {
+	runes := []rune(text)
+	if len(runes) <= maximumRenderedText {
+		return text
+	}
+	return string(runes[:maximumRenderedText]) + "..."
+}
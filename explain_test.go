@@ -0,0 +1,140 @@
+package licensechecker
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/ledongthuc/licensechecker/internal/data"
+)
+
+func TestDetect_explain(t *testing.T) {
+	content, _ := data.Asset("BSD-3-Clause.txt")
+	got, err := DetectWithOptions(content, DetectOptions{Explain: true})
+	if err != nil {
+		t.Errorf("DetectWithOptions() error = %v", err)
+		return
+	}
+	explanation := got.Explanation
+	if explanation == nil || len(explanation.Candidates) < 2 {
+		t.Errorf("DetectWithOptions() explanation = %+v, want the best match and competitors", explanation)
+		return
+	}
+	if explanation.Format != "plain" || len(explanation.Steps) == 0 {
+		t.Errorf("DetectWithOptions() explanation format = %v, steps = %v", explanation.Format, explanation.Steps)
+	}
+
+	best := explanation.Candidates[0]
+	if best.LicenseID != "BSD-3-Clause" || best.Confidence != got.Confidence {
+		t.Errorf("DetectWithOptions() explained %v with confidence %v, want BSD-3-Clause with %v", best.LicenseID, best.Confidence, got.Confidence)
+	}
+	if len(best.KeyPhrases) == 0 || string(content[best.KeyPhrases[0].Start:best.KeyPhrases[0].End]) == "" {
+		t.Errorf("DetectWithOptions() key phrases = %v", best.KeyPhrases)
+	}
+	found := strings.Join(best.FoundClauses, "\n")
+	if !strings.Contains(found, "Neither the name of the copyright holder") {
+		t.Errorf("DetectWithOptions() found clauses = %v, want the non-endorsement clause", best.FoundClauses)
+	}
+	if len(best.MissingClauses) != 0 {
+		t.Errorf("DetectWithOptions() missing clauses = %v, want none", best.MissingClauses)
+	}
+}
+
+func TestDetect_explainIsOptional(t *testing.T) {
+	got, err := DetectWithOptions([]byte(exampleMITContent), DetectOptions{})
+	if err != nil {
+		t.Errorf("DetectWithOptions() error = %v", err)
+		return
+	}
+	if got.Explanation != nil {
+		t.Errorf("DetectWithOptions() explanation = %+v, want nil", got.Explanation)
+	}
+}
+
+func TestExplanation_render(t *testing.T) {
+	explanation := Explanation{
+		Format: "markdown",
+		Steps:  []string{"case", "whitespace"},
+		Candidates: []CandidateEvidence{
+			{
+				LicenseID:      "BSD-3-Clause",
+				Confidence:     0.99,
+				Coverage:       1,
+				KeyPhrases:     []Phrase{{Text: "Redistribution and use", Start: 10, End: 32}},
+				FoundClauses:   []string{"Neither the name of the copyright holder nor the names of its contributors may be used to endorse or promote products"},
+				MissingClauses: []string{},
+			},
+		},
+	}
+
+	text := explanation.Text()
+	for _, want := range []string{
+		"Format: markdown",
+		"Normalization: case, whitespace",
+		"1. BSD-3-Clause (confidence 0.990, coverage 1.000)",
+		`[10, 32) "Redistribution and use"`,
+		"+ Neither the name of the copyright holder nor the names of its contributors may b...",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("Text() = %s, want %q", text, want)
+		}
+	}
+
+	content, err := explanation.JSON()
+	if err != nil {
+		t.Errorf("JSON() error = %v", err)
+		return
+	}
+	decoded := Explanation{}
+	if err := json.Unmarshal(content, &decoded); err != nil {
+		t.Errorf("JSON() = %s, error = %v", content, err)
+		return
+	}
+	if decoded.Candidates[0].KeyPhrases[0] != explanation.Candidates[0].KeyPhrases[0] || !strings.Contains(string(content), `"normalizationSteps"`) {
+		t.Errorf("JSON() = %s", content)
+	}
+}
+
+func TestDetectResult_JSON(t *testing.T) {
+	content, _ := data.Asset("BSD-3-Clause.txt")
+	got, err := DetectWithOptions(content, DetectOptions{Explain: true})
+	if err != nil {
+		t.Errorf("DetectWithOptions() error = %v", err)
+		return
+	}
+	encoded, err := got.JSON()
+	if err != nil {
+		t.Errorf("JSON() error = %v", err)
+		return
+	}
+	report := Report{}
+	if err := json.Unmarshal(encoded, &report); err != nil {
+		t.Errorf("JSON() = %s, error = %v", encoded, err)
+		return
+	}
+	if report.LicenseID != "BSD-3-Clause" || report.Expression != "BSD-3-Clause" || report.Scope != "none" ||
+		report.Confidence != got.Confidence || report.Explanation == nil || len(report.Explanation.Candidates) == 0 {
+		t.Errorf("JSON() = %s, want summary of BSD-3-Clause with explanation", encoded)
+	}
+}
+
+func Test_corpusEntry_licenseClauses(t *testing.T) {
+	c, err := loadCorpus()
+	if err != nil {
+		t.Errorf("loadCorpus() error = %v", err)
+		return
+	}
+	var entry corpusEntry
+	for _, e := range c.entries {
+		if e.info.LicenseID == "BSD-3-Clause" {
+			entry = e
+		}
+	}
+	first := entry.licenseClauses()
+	if len(first) == 0 || len(entry.clauses.clauses) != len(first) {
+		t.Errorf("licenseClauses() = %v clauses, cached %v", len(first), len(entry.clauses.clauses))
+	}
+	if second := entry.licenseClauses(); &second[0] != &first[0] {
+		t.Errorf("licenseClauses() isn't cached")
+	}
+}
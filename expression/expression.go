@@ -0,0 +1,92 @@
+package expression
+
+import (
+	"strings"
+)
+
+const (
+	licenseRefPrefix  = "LicenseRef-"
+	documentRefPrefix = "DocumentRef-"
+)
+
+// Expression is a node of SPDX license expression syntax tree: License, With, And or Or
+type Expression interface {
+	// String prints expression in canonical form, operators are upper case and only needed parentheses are kept
+	String() string
+	isExpression()
+}
+
+// License is a license ID, e.g. "MIT", "GPL-2.0+", "LicenseRef-Custom" or "DocumentRef-spdx-tool:LicenseRef-Custom"
+type License struct {
+	ID string
+	// OrLater is "+" after ID, which means the license version or any later one
+	OrLater bool
+}
+
+// With is a license with an exception, e.g. "GPL-2.0-only WITH Classpath-exception-2.0"
+type With struct {
+	License   License
+	Exception string
+}
+
+// And is a conjunction, both licenses must be complied with
+type And struct {
+	Left, Right Expression
+}
+
+// Or is a disjunction, either license can be chosen
+type Or struct {
+	Left, Right Expression
+}
+
+func (License) isExpression() {}
+func (With) isExpression()    {}
+func (And) isExpression()     {}
+func (Or) isExpression()      {}
+
+func (l License) String() string {
+	if l.OrLater {
+		return l.ID + "+"
+	}
+	return l.ID
+}
+
+// IsRef checks whether license is a user defined "LicenseRef-" or "DocumentRef-", which isn't in SPDX license list
+func (l License) IsRef() bool {
+	return strings.HasPrefix(l.ID, licenseRefPrefix) || strings.HasPrefix(l.ID, documentRefPrefix)
+}
+
+func (w With) String() string {
+	return w.License.String() + " WITH " + w.Exception
+}
+
+func (a And) String() string {
+	return operand(a.Left, true) + " AND " + operand(a.Right, true)
+}
+
+func (o Or) String() string {
+	return operand(o.Left, false) + " OR " + operand(o.Right, false)
+}
+
+// operand prints an operand of AND or OR, OR inside of AND needs parentheses because AND binds tighter
+func operand(e Expression, insideAnd bool) string {
+	if _, ok := e.(Or); ok && insideAnd {
+		return "(" + e.String() + ")"
+	}
+	return e.String()
+}
+
+// Licenses returns all licenses of expression from left to right, licenses of WITH are included
+func Licenses(e Expression) []License {
+	switch node := e.(type) {
+	case License:
+		return []License{node}
+	case With:
+		return []License{node.License}
+	case And:
+		return append(Licenses(node.Left), Licenses(node.Right)...)
+	case Or:
+		return append(Licenses(node.Left), Licenses(node.Right)...)
+	}
+	return nil
+}
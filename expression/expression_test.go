@@ -0,0 +1,45 @@
+package expression
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExpression_String(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{text: "mit  or (apache-2.0)", want: "mit OR apache-2.0"},
+		{text: "(MIT OR Apache-2.0) AND (BSD-2-Clause AND ISC)", want: "(MIT OR Apache-2.0) AND BSD-2-Clause AND ISC"},
+		{text: "MIT OR (Apache-2.0 AND ISC)", want: "MIT OR Apache-2.0 AND ISC"},
+		{text: "GPL-2.0+ with Classpath-exception-2.0", want: "GPL-2.0+ WITH Classpath-exception-2.0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			e, err := Parse(tt.text)
+			if err != nil {
+				t.Errorf("Parse() error = %v", err)
+				return
+			}
+			if got := e.String(); got != tt.want {
+				t.Errorf("String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLicenses(t *testing.T) {
+	e, err := Parse("(MIT OR GPL-2.0+ WITH Classpath-exception-2.0) AND LicenseRef-Custom")
+	if err != nil {
+		t.Errorf("Parse() error = %v", err)
+		return
+	}
+	want := []License{{ID: "MIT"}, {ID: "GPL-2.0", OrLater: true}, {ID: "LicenseRef-Custom"}}
+	if got := Licenses(e); !reflect.DeepEqual(got, want) {
+		t.Errorf("Licenses() = %v, want %v", got, want)
+	}
+	if !want[2].IsRef() || want[0].IsRef() {
+		t.Errorf("IsRef() doesn't recognize only references")
+	}
+}
@@ -0,0 +1,203 @@
+package expression
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrorInvalidExpression = errors.New("Invalid SPDX license expression")
+)
+
+var (
+	// idPattern is idstring of SPDX, and ":" that separates DocumentRef from LicenseRef
+	idPattern          = regexp.MustCompile(`^[A-Za-z0-9.\-:]+`)
+	licenseRefPattern  = regexp.MustCompile(`(?i)^licenseref-([A-Za-z0-9.\-]+)$`)
+	documentRefPattern = regexp.MustCompile(`(?i)^documentref-([A-Za-z0-9.\-]+):licenseref-([A-Za-z0-9.\-]+)$`)
+)
+
+// token is a word, parenthesis or "+" of expression at byte position of text
+type token struct {
+	text     string
+	position int
+}
+
+// tokenize breaks text into tokens, whitespaces are skipped
+func tokenize(text string) ([]token, error) {
+	tokens := []token{}
+	for position := 0; position < len(text); {
+		switch c := text[position]; {
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+			position++
+		case c == '(' || c == ')' || c == '+':
+			tokens = append(tokens, token{text: string(c), position: position})
+			position++
+		default:
+			word := idPattern.FindString(text[position:])
+			if word == "" {
+				return nil, syntaxError(position, "unexpected character '"+string(c)+"'")
+			}
+			tokens = append(tokens, token{text: word, position: position})
+			position += len(word)
+		}
+	}
+	return tokens, nil
+}
+
+// parser is a recursive descent parser of SPDX operator precedence: "+", WITH, AND, then OR
+type parser struct {
+	text     string
+	tokens   []token
+	position int
+}
+
+// Parse parses SPDX license expression, e.g. "(MIT OR Apache-2.0) AND GPL-2.0-or-later WITH Classpath-exception-2.0".
+// Operators are upper case or lower case. IDs aren't checked with SPDX license list, see Validate.
+func Parse(text string) (Expression, error) {
+	tokens, err := tokenize(text)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{text: text, tokens: tokens}
+	e, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t, ok := p.peek(); ok {
+		return nil, syntaxError(t.position, "unexpected '"+t.text+"'")
+	}
+	return e, nil
+}
+
+// peek returns the next token without consuming it
+func (p *parser) peek() (token, bool) {
+	if p.position >= len(p.tokens) {
+		return token{}, false
+	}
+	return p.tokens[p.position], true
+}
+
+// acceptOperator consumes the next token if it's operator in upper case or lower case
+func (p *parser) acceptOperator(operator string) bool {
+	t, ok := p.peek()
+	if !ok || (t.text != operator && t.text != strings.ToLower(operator)) {
+		return false
+	}
+	p.position++
+	return true
+}
+
+func (p *parser) parseOr() (Expression, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.acceptOperator("OR") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = Or{Left: left, Right: right}
+	}
+	return left, nil
+}
+
+func (p *parser) parseAnd() (Expression, error) {
+	left, err := p.parseWith()
+	if err != nil {
+		return nil, err
+	}
+	for p.acceptOperator("AND") {
+		right, err := p.parseWith()
+		if err != nil {
+			return nil, err
+		}
+		left = And{Left: left, Right: right}
+	}
+	return left, nil
+}
+
+// parseWith parses a parenthesized expression, or a license with optional exception
+func (p *parser) parseWith() (Expression, error) {
+	t, ok := p.peek()
+	if !ok {
+		return nil, syntaxError(len(p.text), "expression ends without license")
+	}
+	if t.text == "(" {
+		p.position++
+		e, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if next, ok := p.peek(); !ok || next.text != ")" {
+			return nil, syntaxError(t.position, "'(' isn't closed")
+		}
+		p.position++
+		return e, nil
+	}
+
+	license, err := p.parseLicense()
+	if err != nil {
+		return nil, err
+	}
+	if !p.acceptOperator("WITH") {
+		return license, nil
+	}
+	t, ok = p.peek()
+	if !ok {
+		return nil, syntaxError(len(p.text), "WITH isn't followed by exception")
+	}
+	if !isWord(t.text) {
+		return nil, syntaxError(t.position, "expected exception instead of '"+t.text+"'")
+	}
+	p.position++
+	return With{License: license, Exception: t.text}, nil
+}
+
+// parseLicense parses license ID with optional "+", or a reference of user defined license
+func (p *parser) parseLicense() (License, error) {
+	t, ok := p.peek()
+	if !ok {
+		return License{}, syntaxError(len(p.text), "expression ends without license")
+	}
+	if !isWord(t.text) {
+		return License{}, syntaxError(t.position, "expected license instead of '"+t.text+"'")
+	}
+	p.position++
+
+	license := License{ID: t.text}
+	if match := documentRefPattern.FindStringSubmatch(t.text); match != nil {
+		license.ID = documentRefPrefix + match[1] + ":" + licenseRefPrefix + match[2]
+	} else if match := licenseRefPattern.FindStringSubmatch(t.text); match != nil {
+		license.ID = licenseRefPrefix + match[1]
+	} else if strings.Contains(t.text, ":") {
+		return License{}, syntaxError(t.position, "invalid reference '"+t.text+"'")
+	}
+
+	// "+" must directly follow license ID
+	if next, ok := p.peek(); ok && next.text == "+" && next.position == t.position+len(t.text) {
+		if license.IsRef() {
+			return License{}, syntaxError(next.position, "'+' can't follow '"+license.ID+"'")
+		}
+		p.position++
+		license.OrLater = true
+	}
+	return license, nil
+}
+
+// isWord checks whether token is an ID instead of parenthesis, "+" or operator
+func isWord(text string) bool {
+	switch strings.ToUpper(text) {
+	case "(", ")", "+", "AND", "OR", "WITH":
+		return false
+	}
+	return true
+}
+
+// syntaxError tells reason of invalid expression at byte position
+func syntaxError(position int, reason string) error {
+	return errors.Wrap(ErrorInvalidExpression, reason+" at position "+strconv.Itoa(position))
+}
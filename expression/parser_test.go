@@ -0,0 +1,72 @@
+package expression
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		text    string
+		want    Expression
+		wantErr error
+	}{
+		{
+			name: "License",
+			text: "MIT",
+			want: License{ID: "MIT"},
+		},
+		{
+			name: "Or later",
+			text: "GPL-2.0+",
+			want: License{ID: "GPL-2.0", OrLater: true},
+		},
+		{
+			name: "AND binds tighter than OR",
+			text: "MIT OR Apache-2.0 AND BSD-3-Clause",
+			want: Or{Left: License{ID: "MIT"}, Right: And{Left: License{ID: "Apache-2.0"}, Right: License{ID: "BSD-3-Clause"}}},
+		},
+		{
+			name: "Parentheses and WITH",
+			text: "(MIT OR Apache-2.0) AND GPL-2.0-or-later WITH Classpath-exception-2.0",
+			want: And{
+				Left:  Or{Left: License{ID: "MIT"}, Right: License{ID: "Apache-2.0"}},
+				Right: With{License: License{ID: "GPL-2.0-or-later"}, Exception: "Classpath-exception-2.0"},
+			},
+		},
+		{
+			name: "Lower case operators",
+			text: "mit or gpl-2.0+ with classpath-exception-2.0",
+			want: Or{Left: License{ID: "mit"}, Right: With{License: License{ID: "gpl-2.0", OrLater: true}, Exception: "classpath-exception-2.0"}},
+		},
+		{
+			name: "References",
+			text: "licenseref-Custom AND DocumentRef-spdx-tool-1.2:LicenseRef-MIT-Style-2",
+			want: And{Left: License{ID: "LicenseRef-Custom"}, Right: License{ID: "DocumentRef-spdx-tool-1.2:LicenseRef-MIT-Style-2"}},
+		},
+		{name: "Empty", text: " ", wantErr: ErrorInvalidExpression},
+		{name: "Unclosed parenthesis", text: "(MIT OR Apache-2.0", wantErr: ErrorInvalidExpression},
+		{name: "Missing operand", text: "MIT AND", wantErr: ErrorInvalidExpression},
+		{name: "Missing operator", text: "MIT Apache-2.0", wantErr: ErrorInvalidExpression},
+		{name: "Separated plus", text: "GPL-2.0 +", wantErr: ErrorInvalidExpression},
+		{name: "Plus after reference", text: "LicenseRef-Custom+", wantErr: ErrorInvalidExpression},
+		{name: "WITH compound expression", text: "(MIT OR Apache-2.0) WITH Classpath-exception-2.0", wantErr: ErrorInvalidExpression},
+		{name: "Invalid reference", text: "MIT:LicenseRef-Custom", wantErr: ErrorInvalidExpression},
+		{name: "Invalid character", text: "MIT/Apache-2.0", wantErr: ErrorInvalidExpression},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.text)
+			if errors.Cause(err) != tt.wantErr {
+				t.Errorf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Parse() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,201 @@
+package expression
+
+import (
+	"encoding/json"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/ledongthuc/licensechecker/internal/toc"
+	"github.com/pkg/errors"
+)
+
+const (
+	// maximumSuggestions is the highest number of suggested IDs for an unknown ID
+	maximumSuggestions = 3
+	// maximumSuggestionDistance is the highest edit distance between an unknown ID and its suggestions
+	maximumSuggestionDistance = 3
+)
+
+var (
+	ErrorUnknownLicense   = errors.New("Unknown SPDX license ID")
+	ErrorUnknownException = errors.New("Unknown SPDX license exception ID")
+	ErrorNotLicense       = errors.New("Exception ID is used as license")
+	ErrorNotException     = errors.New("License ID is used as exception")
+)
+
+// Problem is an ID of expression that isn't valid, and valid IDs that are close to it
+type Problem struct {
+	ID string
+	// Err is ErrorUnknownLicense, ErrorUnknownException, ErrorNotLicense or ErrorNotException
+	Err         error
+	Suggestions []string
+}
+
+func (p Problem) Error() string {
+	message := p.Err.Error() + " '" + p.ID + "'"
+	if len(p.Suggestions) > 0 {
+		message += ", did you mean " + strings.Join(p.Suggestions, ", ") + "?"
+	}
+	return message
+}
+
+// idList maps lower case IDs of SPDX license list into their canonical case
+type idList map[string]string
+
+var (
+	loadedListsOnce  sync.Once
+	loadedLicenses   idList
+	loadedExceptions idList
+	loadedListsErr   error
+)
+
+// loadLists loads IDs of licenses and exceptions from SPDX license list once
+func loadLists() (idList, idList, error) {
+	loadedListsOnce.Do(func() {
+		var licenses struct {
+			Licenses []struct {
+				LicenseID string `json:"licenseId"`
+			} `json:"licenses"`
+		}
+		var exceptions struct {
+			Exceptions []struct {
+				LicenseExceptionID string `json:"licenseExceptionId"`
+			} `json:"exceptions"`
+		}
+		for name, list := range map[string]interface{}{"licenses.json": &licenses, "exceptions.json": &exceptions} {
+			raw, err := toc.Asset(name)
+			if err != nil {
+				loadedListsErr = errors.Wrap(err, "Error when load '"+name+"'")
+				return
+			}
+			if err := json.Unmarshal(raw, list); err != nil {
+				loadedListsErr = errors.Wrap(err, "Error when parsing '"+name+"'")
+				return
+			}
+		}
+		loadedLicenses = make(idList, len(licenses.Licenses))
+		for _, l := range licenses.Licenses {
+			loadedLicenses[strings.ToLower(l.LicenseID)] = l.LicenseID
+		}
+		loadedExceptions = make(idList, len(exceptions.Exceptions))
+		for _, e := range exceptions.Exceptions {
+			loadedExceptions[strings.ToLower(e.LicenseExceptionID)] = e.LicenseExceptionID
+		}
+	})
+	return loadedLicenses, loadedExceptions, loadedListsErr
+}
+
+// Validate checks IDs of expression with SPDX license list. IDs are matched case-insensitively, and the returned
+// expression has them in canonical case, e.g. "mit OR apache-2.0" becomes "MIT OR Apache-2.0". References of user
+// defined licenses aren't checked. Unknown IDs are returned as problems with suggestions of close IDs.
+func Validate(e Expression) (Expression, []Problem, error) {
+	licenses, exceptions, err := loadLists()
+	if err != nil {
+		return nil, nil, err
+	}
+	problems := []Problem{}
+	return validate(e, licenses, exceptions, &problems), problems, nil
+}
+
+// validate canonicalizes IDs of expression, and collects its problems
+func validate(e Expression, licenses, exceptions idList, problems *[]Problem) Expression {
+	switch node := e.(type) {
+	case License:
+		if node.IsRef() {
+			return node
+		}
+		node.ID = canonicalID(node.ID, licenses, exceptions, ErrorUnknownLicense, ErrorNotLicense, problems)
+		return node
+	case With:
+		node.License = validate(node.License, licenses, exceptions, problems).(License)
+		node.Exception = canonicalID(node.Exception, exceptions, licenses, ErrorUnknownException, ErrorNotException, problems)
+		return node
+	case And:
+		return And{Left: validate(node.Left, licenses, exceptions, problems), Right: validate(node.Right, licenses, exceptions, problems)}
+	case Or:
+		return Or{Left: validate(node.Left, licenses, exceptions, problems), Right: validate(node.Right, licenses, exceptions, problems)}
+	}
+	return e
+}
+
+// canonicalID looks up ID in list that it belongs to. An ID of the other list is a misuse, e.g. an exception is used as
+// license, otherwise the ID is unknown.
+func canonicalID(id string, list, other idList, unknown, misused error, problems *[]Problem) string {
+	if canonical, ok := list[strings.ToLower(id)]; ok {
+		return canonical
+	}
+	if canonical, ok := other[strings.ToLower(id)]; ok {
+		*problems = append(*problems, Problem{ID: canonical, Err: misused, Suggestions: suggest(id, list)})
+		return canonical
+	}
+	*problems = append(*problems, Problem{ID: id, Err: unknown, Suggestions: suggest(id, list)})
+	return id
+}
+
+// suggest finds IDs of list that are the closest to an unknown ID by edit distance. IDs that start with the unknown
+// ID and a "-" are suggested too, e.g. "Apache" has suggestions of its versions.
+func suggest(id string, list idList) []string {
+	type suggestion struct {
+		id       string
+		distance int
+	}
+	id = strings.ToLower(id)
+	suggestions := []suggestion{}
+	for key, canonical := range list {
+		d := editDistance(id, key)
+		if strings.HasPrefix(key, id+"-") && d > maximumSuggestionDistance {
+			d = maximumSuggestionDistance
+		}
+		if d <= maximumSuggestionDistance && d < len(id) {
+			suggestions = append(suggestions, suggestion{id: canonical, distance: d})
+		}
+	}
+	sort.Slice(suggestions, func(i, j int) bool {
+		if suggestions[i].distance != suggestions[j].distance {
+			return suggestions[i].distance < suggestions[j].distance
+		}
+		return suggestions[i].id < suggestions[j].id
+	})
+	result := []string{}
+	for index := 0; index < len(suggestions) && index < maximumSuggestions; index++ {
+		result = append(result, suggestions[index].id)
+	}
+	return result
+}
+
+// editDistance is Levenshtein distance where a transposition of 2 adjacent characters is 1 edit, so "MTI" is close to
+// "MIT" as other typos
+func editDistance(a, b string) int {
+	previous2 := make([]int, len(b)+1)
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = minimum(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+			if i > 1 && j > 1 && a[i-1] == b[j-2] && a[i-2] == b[j-1] {
+				current[j] = minimum(current[j], previous2[j-2]+1)
+			}
+		}
+		previous2, previous, current = previous, current, previous2
+	}
+	return previous[len(b)]
+}
+
+func minimum(values ...int) int {
+	result := values[0]
+	for _, value := range values[1:] {
+		if value < result {
+			result = value
+		}
+	}
+	return result
+}
@@ -0,0 +1,95 @@
+package expression
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		want     string
+		problems []Problem
+	}{
+		{
+			name:     "Canonical case",
+			text:     "mit or apache-2.0 and gpl-2.0-or-later with classpath-exception-2.0",
+			want:     "MIT OR Apache-2.0 AND GPL-2.0-or-later WITH Classpath-exception-2.0",
+			problems: []Problem{},
+		},
+		{
+			name:     "Deprecated ID and references",
+			text:     "GPL-2.0+ OR LicenseRef-Custom",
+			want:     "GPL-2.0+ OR LicenseRef-Custom",
+			problems: []Problem{},
+		},
+		{
+			name:     "Typo",
+			text:     "MTI",
+			want:     "MTI",
+			problems: []Problem{{ID: "MTI", Err: ErrorUnknownLicense, Suggestions: []string{"MIT", "FTL", "MTLL"}}},
+		},
+		{
+			name:     "Missing version",
+			text:     "GPL-2.0-only WITH classpath-exception",
+			want:     "GPL-2.0-only WITH classpath-exception",
+			problems: []Problem{{ID: "classpath-exception", Err: ErrorUnknownException, Suggestions: []string{"Classpath-exception-2.0"}}},
+		},
+		{
+			name:     "Exception as license",
+			text:     "MIT AND classpath-exception-2.0",
+			want:     "MIT AND Classpath-exception-2.0",
+			problems: []Problem{{ID: "Classpath-exception-2.0", Err: ErrorNotLicense, Suggestions: []string{}}},
+		},
+		{
+			name:     "License as exception",
+			text:     "GPL-2.0-only WITH MIT",
+			want:     "GPL-2.0-only WITH MIT",
+			problems: []Problem{{ID: "MIT", Err: ErrorNotException, Suggestions: []string{}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := Parse(tt.text)
+			if err != nil {
+				t.Errorf("Parse() error = %v", err)
+				return
+			}
+			got, problems, err := Validate(e)
+			if err != nil {
+				t.Errorf("Validate() error = %v", err)
+				return
+			}
+			if got.String() != tt.want || !reflect.DeepEqual(problems, tt.problems) {
+				t.Errorf("Validate() = %v, %v, want %v, %v", got, problems, tt.want, tt.problems)
+			}
+		})
+	}
+}
+
+func TestProblem_Error(t *testing.T) {
+	problem := Problem{ID: "Apache2", Err: ErrorUnknownLicense, Suggestions: []string{"Apache-2.0"}}
+	if want := "Unknown SPDX license ID 'Apache2', did you mean Apache-2.0?"; problem.Error() != want {
+		t.Errorf("Error() = %v, want %v", problem.Error(), want)
+	}
+}
+
+func Test_editDistance(t *testing.T) {
+	tests := []struct {
+		a, b string
+		want int
+	}{
+		{a: "mit", b: "mit", want: 0},
+		{a: "mti", b: "mit", want: 1},
+		{a: "apache2", b: "apache-2.0", want: 3},
+		{a: "", b: "isc", want: 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.a+"-"+tt.b, func(t *testing.T) {
+			if got := editDistance(tt.a, tt.b); got != tt.want {
+				t.Errorf("editDistance() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,244 @@
+package format
+
+import (
+	"html"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// Format is markup language of license content
+type Format int
+
+const (
+	// Unknown format is sniffed from content
+	Unknown Format = iota
+	// Plain text is kept as is
+	Plain
+	Markdown
+	HTML
+	RST
+)
+
+// names are names of formats, they're used in explanations of detection
+var names = map[Format]string{
+	Unknown:  "unknown",
+	Plain:    "plain",
+	Markdown: "markdown",
+	HTML:     "html",
+	RST:      "rst",
+}
+
+func (f Format) String() string {
+	if name, ok := names[f]; ok {
+		return name
+	}
+	return "unknown"
+}
+
+// extensions are formats by file extensions
+var extensions = map[string]Format{
+	".txt":      Plain,
+	".md":       Markdown,
+	".markdown": Markdown,
+	".html":     HTML,
+	".htm":      HTML,
+	".rst":      RST,
+}
+
+var (
+	htmlSniffPattern     = regexp.MustCompile(`(?i)<(?:!doctype|html|body|p|div|br|h[1-6]|pre|li|a\s)[\s>/]`)
+	markdownSniffPattern = regexp.MustCompile("(?m)^#{1,6}\\s+\\S|^```|\\[[^\\]\\n]+\\]\\([^)\\n]+\\)|\\*\\*[^*\\n]+\\*\\*")
+	rstSniffPattern      = regexp.MustCompile("(?m)^\\.\\. \\S|`[^`\\n]+ <[^>\\n]+>`_|^:\\w+:")
+)
+
+// Sniff finds format of content by extension of its file name, then by its content. File name can be empty.
+func Sniff(content []byte, fileName string) Format {
+	if format, ok := extensions[strings.ToLower(filepath.Ext(fileName))]; ok {
+		return format
+	}
+	switch {
+	case htmlSniffPattern.Match(content):
+		return HTML
+	case rstSniffPattern.Match(content):
+		return RST
+	case markdownSniffPattern.Match(content):
+		return Markdown
+	}
+	return Plain
+}
+
+// rule replaces every match of pattern by its submatch at keep, or by replacement text if keep is negative.
+// Matches of decoding rule are replaced by their decoded HTML entities.
+type rule struct {
+	pattern     *regexp.Regexp
+	keep        int
+	replacement string
+	decode      bool
+}
+
+var (
+	commentRule = rule{pattern: regexp.MustCompile(`<!--[\s\S]*?-->`), keep: -1}
+	entityRule  = rule{pattern: regexp.MustCompile(`&(?:#[0-9]+|#[xX][0-9a-fA-F]+|[a-zA-Z][a-zA-Z0-9]*);`), keep: -1, decode: true}
+	tagRule     = rule{pattern: regexp.MustCompile(`</?[a-zA-Z][^<>]*>`), keep: -1}
+	// inlineTagRule only removes common tags, so placeholders of license texts like "<year>" stay in Markdown
+	inlineTagRule = rule{pattern: regexp.MustCompile(`(?i)</?(?:a|b|i|u|em|strong|p|br|div|span|img|sup|sub|code|pre|h[1-6]|ul|ol|li|table|tr|td|th|details|summary|center|hr)\b[^<>]*>`), keep: -1}
+)
+
+var markdownRules = []rule{
+	commentRule,
+	{pattern: regexp.MustCompile("(?m)^[ \\t]*(?:```|~~~).*$"), keep: -1},
+	{pattern: regexp.MustCompile(`!\[[^\]\n]*\]\([^)\n]*\)`), keep: -1},
+	{pattern: regexp.MustCompile(`!\[[^\]\n]*\]\[[^\]\n]*\]`), keep: -1},
+	{pattern: regexp.MustCompile(`\[([^\]\n]*)\]\([^)\n]*\)`), keep: 1},
+	{pattern: regexp.MustCompile(`\[([^\]\n]+)\]\[[^\]\n]*\]`), keep: 1},
+	{pattern: regexp.MustCompile(`(?m)^[ \t]*\[[^\]\n]+\]:[ \t]*\S+.*$`), keep: -1},
+	{pattern: regexp.MustCompile(`<((?:https?|ftp)://[^>\s]+)>`), keep: 1},
+	{pattern: regexp.MustCompile(`(?m)^[ \t]*#{1,6}[ \t]+`), keep: -1},
+	{pattern: regexp.MustCompile(`\*{1,3}([^*\n]+?)\*{1,3}`), keep: 1},
+	{pattern: regexp.MustCompile(`\b_{1,3}([^_\n]+?)_{1,3}\b`), keep: 1},
+	{pattern: regexp.MustCompile("`([^`\\n]+)`"), keep: 1},
+	inlineTagRule,
+	entityRule,
+}
+
+var htmlRules = []rule{
+	commentRule,
+	{pattern: regexp.MustCompile(`(?is)<(script|style)\b.*?</(?:script|style)\s*>`), keep: -1},
+	// Block tags break lines, so lines of license text are kept
+	{pattern: regexp.MustCompile(`(?i)</?(?:p|br|div|li|ul|ol|h[1-6]|tr|pre|blockquote|hr)\b[^<>]*>`), keep: -1, replacement: "\n"},
+	tagRule,
+	entityRule,
+}
+
+var rstRules = []rule{
+	{pattern: regexp.MustCompile(`(?m)^\.\. .*$`), keep: -1},
+	{pattern: regexp.MustCompile(`(?m)^[ \t]*(?:={3,}|-{3,}|~{3,}|\^{3,}|\*{3,}|\+{3,}|#{3,}|"{3,}|'{3,})[ \t]*$`), keep: -1},
+	{pattern: regexp.MustCompile("`([^`<\\n]+?)\\s*<[^>\\n]+>`__?"), keep: 1},
+	{pattern: regexp.MustCompile("(?::[\\w-]+:)?`([^`\\n]+)`__?"), keep: 1},
+	{pattern: regexp.MustCompile("``([^`\\n]+)``"), keep: 1},
+	{pattern: regexp.MustCompile(`\*{1,2}([^*\n]+?)\*{1,2}`), keep: 1},
+}
+
+// Text is stripped text of content. Every byte of text keeps the byte range of original content that it comes from.
+// Text without offsets is the same as content, its offsets are built once a rule changes it.
+type Text struct {
+	Text   string
+	starts []int
+	ends   []int
+}
+
+// withOffsets returns text with offsets of its bytes, they're built if text is the same as content
+func (t Text) withOffsets() Text {
+	if t.starts != nil {
+		return t
+	}
+	t.starts, t.ends = make([]int, len(t.Text)), make([]int, len(t.Text))
+	for index := range t.starts {
+		t.starts[index] = index
+		t.ends[index] = index + 1
+	}
+	return t
+}
+
+// Strip removes markup of format from content, and keeps text that is read by people. Link targets, images,
+// comments, tags, heading and emphasis markers are removed, entities are decoded. Unknown format is sniffed.
+// Plain text and text without markup are returned as is.
+func Strip(content []byte, format Format) Text {
+	if format == Unknown {
+		format = Sniff(content, "")
+	}
+
+	var rules []rule
+	switch format {
+	case Markdown:
+		rules = markdownRules
+	case HTML:
+		rules = htmlRules
+	case RST:
+		rules = rstRules
+	}
+
+	t := Text{Text: string(content)}
+	for _, r := range rules {
+		t = t.apply(r)
+	}
+	return t
+}
+
+// apply replaces matches of rule in text. Kept submatch keeps its offsets, replacement text takes range of the whole match.
+func (t Text) apply(r rule) Text {
+	locs := r.pattern.FindAllStringSubmatchIndex(t.Text, -1)
+	if len(locs) == 0 {
+		return t
+	}
+
+	t = t.withOffsets()
+	result := Text{starts: make([]int, 0, len(t.starts)), ends: make([]int, 0, len(t.ends))}
+	var builder strings.Builder
+	keep := func(start, end int) {
+		builder.WriteString(t.Text[start:end])
+		result.starts = append(result.starts, t.starts[start:end]...)
+		result.ends = append(result.ends, t.ends[start:end]...)
+	}
+
+	position := 0
+	for _, loc := range locs {
+		keep(position, loc[0])
+		position = loc[1]
+
+		if r.keep >= 0 {
+			if loc[2*r.keep] >= 0 {
+				keep(loc[2*r.keep], loc[2*r.keep+1])
+			}
+			continue
+		}
+		replacement := r.replacement
+		if r.decode {
+			replacement = html.UnescapeString(t.Text[loc[0]:loc[1]])
+		}
+		builder.WriteString(replacement)
+		for range []byte(replacement) {
+			result.starts = append(result.starts, t.starts[loc[0]])
+			result.ends = append(result.ends, t.ends[loc[1]-1])
+		}
+	}
+	keep(position, len(t.Text))
+	result.Text = builder.String()
+	return result
+}
+
+// Span maps byte range [start, end) of text into byte range of original content
+func (t Text) Span(start, end int) (int, int) {
+	if t.starts == nil {
+		start, end = clamp(start, len(t.Text)), clamp(end, len(t.Text))
+		if end < start {
+			end = start
+		}
+		return start, end
+	}
+	if len(t.starts) == 0 {
+		return 0, 0
+	}
+	if start >= len(t.starts) {
+		return t.ends[len(t.ends)-1], t.ends[len(t.ends)-1]
+	}
+	if end <= start {
+		return t.starts[start], t.starts[start]
+	}
+	if end > len(t.ends) {
+		end = len(t.ends)
+	}
+	return t.starts[start], t.ends[end-1]
+}
+
+// clamp limits value into [0, limit]
+func clamp(value, limit int) int {
+	if value < 0 {
+		return 0
+	}
+	if value > limit {
+		return limit
+	}
+	return value
+}
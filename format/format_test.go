@@ -0,0 +1,148 @@
+package format
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSniff(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		fileName string
+		want     Format
+	}{
+		{
+			name:     "By extension",
+			content:  "# MIT License",
+			fileName: "LICENSE.txt",
+			want:     Plain,
+		},
+		{
+			name:    "Markdown heading",
+			content: "# MIT License\n\nPermission is hereby granted",
+			want:    Markdown,
+		},
+		{
+			name:    "Markdown link",
+			content: "Licensed under [MIT](https://opensource.org/licenses/MIT).",
+			want:    Markdown,
+		},
+		{
+			name:    "HTML",
+			content: "<html><body><p>Permission is hereby granted</p></body></html>",
+			want:    HTML,
+		},
+		{
+			name:    "reStructuredText",
+			content: "License\n=======\n\n.. _MIT: https://opensource.org/licenses/MIT\n",
+			want:    RST,
+		},
+		{
+			name:    "Plain text",
+			content: "MIT License\n\nCopyright (c) <year> <copyright holders>\n\n* Permission is hereby granted",
+			want:    Plain,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Sniff([]byte(tt.content), tt.fileName); got != tt.want {
+				t.Errorf("Sniff() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestStrip(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		format  Format
+		want    string
+		// word is a word of stripped text, its span must point at the same word in content
+		word string
+	}{
+		{
+			name:    "Markdown",
+			content: "## The **MIT** License [![badge](https://img.shields.io/badge.svg)](https://ci)\n\nSee [the site](https://example.com) and `code`.",
+			format:  Markdown,
+			want:    "The MIT License \n\nSee the site and code.",
+			word:    "site",
+		},
+		{
+			name:    "HTML",
+			content: "<h1>MIT&nbsp;License</h1><!-- generated --><p>Permission is <b>hereby</b> granted &amp; &#8220;free&#8221;</p>",
+			format:  HTML,
+			want:    "\nMIT License\n\nPermission is hereby granted & “free”\n",
+			word:    "hereby",
+		},
+		{
+			name:    "reStructuredText",
+			content: "MIT License\n===========\n\n.. comment\n\nSee `the site <https://example.com>`_ and **bold** text.",
+			format:  RST,
+			want:    "MIT License\n\n\n\n\nSee the site and bold text.",
+			word:    "bold",
+		},
+		{
+			name:    "Plain text is kept",
+			content: "Permission is **hereby** granted",
+			format:  Plain,
+			want:    "Permission is **hereby** granted",
+			word:    "hereby",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Strip([]byte(tt.content), tt.format)
+			if got.Text != tt.want {
+				t.Errorf("Strip() = %q, want %q", got.Text, tt.want)
+				return
+			}
+			position := strings.Index(got.Text, tt.word)
+			start, end := got.Span(position, position+len(tt.word))
+			if original := tt.content[start:end]; original != tt.word {
+				t.Errorf("Strip().Span() = %q, want %q", original, tt.word)
+			}
+		})
+	}
+}
+
+func TestText_Span(t *testing.T) {
+	text := Strip([]byte("a &amp; b"), HTML)
+	if start, end := text.Span(2, 3); start != 2 || end != 7 {
+		t.Errorf("Span() of decoded entity = [%v, %v), want [2, 7)", start, end)
+	}
+	if start, end := text.Span(len(text.Text), len(text.Text)); start != 9 || end != 9 {
+		t.Errorf("Span() at end = [%v, %v), want [9, 9)", start, end)
+	}
+}
+
+func TestStrip_identity(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		format  Format
+	}{
+		{
+			name:    "Plain text",
+			content: "MIT License\n\n<b>Permission</b> is hereby granted",
+			format:  Plain,
+		},
+		{
+			name:    "Markdown without markup",
+			content: "MIT License\n\nPermission is hereby granted",
+			format:  Markdown,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			text := Strip([]byte(tt.content), tt.format)
+			if text.Text != tt.content || text.starts != nil {
+				t.Errorf("Strip() = %q with offsets %v, want content without offsets", text.Text, text.starts)
+			}
+			if start, end := text.Span(4, 11); start != 4 || end != 11 {
+				t.Errorf("Span() = [%v, %v), want [4, 11)", start, end)
+			}
+		})
+	}
+}
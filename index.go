@@ -0,0 +1,198 @@
+package licensechecker
+
+import (
+	"hash/fnv"
+	"sort"
+)
+
+const (
+	// ngramSize is number of words of every n-gram in the index
+	ngramSize = 3
+	// minimumShortlistScore is the lowest Dice coefficient of n-grams for a license to be shortlisted
+	minimumShortlistScore = 0.05
+	// minimumContainingScore is the lowest fraction of license n-grams found in content for a license to be shortlisted
+	minimumContainingScore = 0.3
+	// windowMargin is number of words around n-grams of a license that are aligned too, so changed words at edges of
+	// the license are kept in its region
+	windowMargin = 10
+)
+
+// ngramIndex is an inverted index from hashed word n-grams of license texts to the licenses that contain them
+type ngramIndex struct {
+	postings map[uint64][]int
+	// sizes are number of distinct n-grams of every license
+	sizes []int
+	// lengths are number of words of every license
+	lengths []int
+}
+
+// scoredEntry is a license of corpus with its n-gram score against inputted content, and the window [first, last) of
+// inputted words where its n-grams are, see densestWindow
+type scoredEntry struct {
+	index int
+	score float64
+	first int
+	last  int
+}
+
+// newNgramIndex builds index of n-grams for all licenses of corpus
+func newNgramIndex(entries []corpusEntry) ngramIndex {
+	index := ngramIndex{
+		postings: make(map[uint64][]int),
+		sizes:    make([]int, len(entries)),
+		lengths:  make([]int, len(entries)),
+	}
+	for entryIndex, entry := range entries {
+		grams := ngrams(entry.words)
+		index.sizes[entryIndex] = len(grams)
+		index.lengths[entryIndex] = len(entry.words)
+		for gram := range grams {
+			index.postings[gram] = append(index.postings[gram], entryIndex)
+		}
+	}
+	return index
+}
+
+// shortlist finds licenses sharing n-grams with inputted words, ranked by Sørensen–Dice coefficient of their n-gram sets.
+// Only licenses that have at least one common n-gram are visited, so cost depends on input size instead of corpus size.
+func (index ngramIndex) shortlist(words []string, limit int) []scoredEntry {
+	return index.rank(words, limit, minimumShortlistScore, func(common, inputSize, entrySize int) float64 {
+		return 2 * float64(common) / float64(inputSize+entrySize)
+	})
+}
+
+// containing finds licenses whose n-grams are found in inputted words, ranked by fraction of their n-grams found.
+// Unlike shortlist, the score isn't lowered by other texts around the license, so it fits content with many licenses.
+func (index ngramIndex) containing(words []string, limit int) []scoredEntry {
+	return index.rank(words, limit, minimumContainingScore, func(common, inputSize, entrySize int) float64 {
+		return float64(common) / float64(entrySize)
+	})
+}
+
+// rank scores licenses that have common n-grams with inputted words, and returns the best ones from minimum score
+// with their windows of inputted words
+func (index ngramIndex) rank(words []string, limit int, minimum float64, score func(common, inputSize, entrySize int) float64) []scoredEntry {
+	hashes := ngramHashes(words)
+	if len(hashes) == 0 {
+		return nil
+	}
+	grams := make(map[uint64]struct{}, len(hashes))
+	for _, hash := range hashes {
+		grams[hash] = struct{}{}
+	}
+
+	common := make(map[int]int)
+	for gram := range grams {
+		for _, entryIndex := range index.postings[gram] {
+			common[entryIndex]++
+		}
+	}
+
+	result := make([]scoredEntry, 0, len(common))
+	for entryIndex, count := range common {
+		s := score(count, len(grams), index.sizes[entryIndex])
+		if s < minimum {
+			continue
+		}
+		result = append(result, scoredEntry{index: entryIndex, score: s})
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].score != result[j].score {
+			return result[i].score > result[j].score
+		}
+		return result[i].index < result[j].index
+	})
+	if len(result) > limit {
+		result = result[:limit]
+	}
+
+	// Positions are collected for the returned licenses only
+	positions := make(map[int][]int, len(result))
+	for _, item := range result {
+		positions[item.index] = []int{}
+	}
+	for position, gram := range hashes {
+		for _, entryIndex := range index.postings[gram] {
+			if _, ok := positions[entryIndex]; ok {
+				positions[entryIndex] = append(positions[entryIndex], position)
+			}
+		}
+	}
+	for i := range result {
+		result[i].first, result[i].last = densestWindow(positions[result[i].index], len(words), index.lengths[result[i].index])
+	}
+	return result
+}
+
+// licenseWindow finds the window of inputted words where n-grams of license words are, see densestWindow
+func licenseWindow(words, license []string) (int, int) {
+	grams := ngrams(license)
+	positions := []int{}
+	for position, gram := range ngramHashes(words) {
+		if _, ok := grams[gram]; ok {
+			positions = append(positions, position)
+		}
+	}
+	return densestWindow(positions, len(words), len(license))
+}
+
+// densestWindow finds the range [first, last) of inputted words that has the most n-grams of a license. The range is
+// at most twice as long as the license plus margins, so words far from the license aren't aligned with it. Positions
+// are starts of common n-grams in order, all words are the window if there isn't any.
+func densestWindow(positions []int, inputLength, licenseLength int) (int, int) {
+	if len(positions) == 0 {
+		return 0, inputLength
+	}
+	width := 2 * licenseLength
+	bestStart, bestEnd := 0, 0
+	end := 0
+	for start := range positions {
+		for end < len(positions) && positions[end] < positions[start]+width {
+			end++
+		}
+		if end-start > bestEnd-bestStart {
+			bestStart, bestEnd = start, end
+		}
+	}
+	first := positions[bestStart] - windowMargin
+	if first < 0 {
+		first = 0
+	}
+	last := positions[bestEnd-1] + ngramSize + windowMargin
+	if last > inputLength {
+		last = inputLength
+	}
+	return first, last
+}
+
+// ngrams hashes all distinct n-grams of words. Text that is shorter than an n-gram becomes one n-gram.
+func ngrams(words []string) map[uint64]struct{} {
+	hashes := ngramHashes(words)
+	result := make(map[uint64]struct{}, len(hashes))
+	for _, hash := range hashes {
+		result[hash] = struct{}{}
+	}
+	return result
+}
+
+// ngramHashes hashes n-grams of words by their start positions. Text that is shorter than an n-gram becomes one n-gram.
+func ngramHashes(words []string) []uint64 {
+	if len(words) == 0 {
+		return nil
+	}
+	size := ngramSize
+	if len(words) < size {
+		size = len(words)
+	}
+
+	result := make([]uint64, 0, len(words)-size+1)
+	for start := 0; start+size <= len(words); start++ {
+		hash := fnv.New64a()
+		for _, word := range words[start : start+size] {
+			hash.Write([]byte(word))
+			hash.Write([]byte{0})
+		}
+		result = append(result, hash.Sum64())
+	}
+	return result
+}
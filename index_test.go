@@ -0,0 +1,159 @@
+package licensechecker
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+func Test_ngrams(t *testing.T) {
+	tests := []struct {
+		name  string
+		words []string
+		want  int
+	}{
+		{
+			name:  "Empty words",
+			words: []string{},
+			want:  0,
+		},
+		{
+			name:  "Shorter than n-gram",
+			words: []string{"mit", "license"},
+			want:  1,
+		},
+		{
+			name:  "Distinct n-grams",
+			words: strings.Fields("permission is hereby granted free of charge"),
+			want:  5,
+		},
+		{
+			name:  "Repeated n-grams",
+			words: strings.Fields("the software the software the software"),
+			want:  2,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ngrams(tt.words); len(got) != tt.want {
+				t.Errorf("ngrams() = %v n-grams, want %v", len(got), tt.want)
+			}
+		})
+	}
+}
+
+func Test_ngramIndex_shortlist(t *testing.T) {
+	entries := []corpusEntry{
+		{info: LicenseInfo{LicenseID: "A"}, words: strings.Fields("permission is hereby granted free of charge to any person")},
+		{info: LicenseInfo{LicenseID: "B"}, words: strings.Fields("redistribution and use in source and binary forms are permitted")},
+		{info: LicenseInfo{LicenseID: "C"}, words: strings.Fields("permission is hereby granted to use copy modify and distribute")},
+	}
+	index := newNgramIndex(entries)
+
+	tests := []struct {
+		name  string
+		words []string
+		limit int
+		want  []string
+	}{
+		{
+			name:  "Ranked by score",
+			words: strings.Fields("permission is hereby granted free of charge"),
+			limit: 5,
+			want:  []string{"A", "C"},
+		},
+		{
+			name:  "Limited",
+			words: strings.Fields("permission is hereby granted free of charge"),
+			limit: 1,
+			want:  []string{"A"},
+		},
+		{
+			name:  "Nothing in common",
+			words: strings.Fields("lorem ipsum dolor sit amet"),
+			limit: 5,
+			want:  []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := index.shortlist(tt.words, tt.limit)
+			if len(got) != len(tt.want) {
+				t.Errorf("shortlist() = %v, want %v", got, tt.want)
+				return
+			}
+			for i, item := range got {
+				if entries[item.index].info.LicenseID != tt.want[i] {
+					t.Errorf("shortlist()[%d] = %v, want %v", i, entries[item.index].info.LicenseID, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func Test_densestWindow(t *testing.T) {
+	tests := []struct {
+		name          string
+		positions     []int
+		inputLength   int
+		licenseLength int
+		first, last   int
+	}{
+		{
+			name:          "Nothing in common",
+			positions:     []int{},
+			inputLength:   100,
+			licenseLength: 10,
+			first:         0,
+			last:          100,
+		},
+		{
+			name:          "Margins are clipped",
+			positions:     []int{2, 3, 4},
+			inputLength:   10,
+			licenseLength: 5,
+			first:         0,
+			last:          10,
+		},
+		{
+			name:          "Far n-grams are left out",
+			positions:     []int{5, 100, 101, 102, 103, 104, 500},
+			inputLength:   1000,
+			licenseLength: 10,
+			first:         90,
+			last:          117,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if first, last := densestWindow(tt.positions, tt.inputLength, tt.licenseLength); first != tt.first || last != tt.last {
+				t.Errorf("densestWindow() = [%v, %v), want [%v, %v)", first, last, tt.first, tt.last)
+			}
+		})
+	}
+}
+
+func Test_alignWords_window(t *testing.T) {
+	license := strings.Fields("permission is hereby granted free of charge to any person")
+	input := append(append(strings.Fields("lorem ipsum dolor"), license...), strings.Fields("sit amet")...)
+	first, last := licenseWindow(input, license)
+	if first != 0 || last != len(input) {
+		t.Errorf("licenseWindow() = [%v, %v), want [0, %v)", first, last, len(input))
+	}
+
+	a := alignWords(input, license, 3, 13)
+	if a.first != 3 || a.last != 13 || a.matched != len(license) {
+		t.Errorf("alignWords() = [%v, %v) with %v matched, want [3, 13) with %v", a.first, a.last, a.matched, len(license))
+	}
+	deleted := 0
+	for _, diff := range a.diffs {
+		if diff.Type == diffmatchpatch.DiffDelete {
+			deleted += utf8.RuneCountInString(diff.Text)
+		}
+	}
+	if deleted != len(input)-len(license) {
+		t.Errorf("alignWords() diffs delete %v words, want words outside of window %v", deleted, len(input)-len(license))
+	}
+}
@@ -0,0 +1,7 @@
+// Package fingerprints has fingerprints of normalized license texts, so verbatim copies of licenses are detected
+// without fuzzy matching. The table is generated into table.go from SPDX license list data with "make update".
+package fingerprints
+
+// Fingerprints maps fingerprints of normalized license texts into SPDX license IDs, it's empty until the table is
+// generated
+var Fingerprints = map[string]string{}
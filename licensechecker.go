@@ -1,7 +1,41 @@
 package licensechecker
 
+import (
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrorNoMatch = errors.New("Can't find any license that matches the content")
+)
+
+// Detect finds the license that is nearest with inputted content. It compares content with all licenses and exceptions of spdx.
+// Markup of content is sniffed and stripped, see DetectOptions.Format.
 func Detect(licenseContent []byte) (LicenseInfo, error) {
-	return LicenseInfo{}, nil
+	result, err := DetectWithOptions(licenseContent, DetectOptions{})
+	if err != nil {
+		return LicenseInfo{}, err
+	}
+	return result.LicenseInfo, nil
+}
+
+// DetectWithOptions finds the best matched license with its confidence and coverage, and also returns other alternative candidates.
+// If there is no license has confidence from MinimumConfidence, ErrorNoMatch is returned together with the alternative candidates.
+func DetectWithOptions(licenseContent []byte, options DetectOptions) (DetectResult, error) {
+	c, err := loadCorpus()
+	if err != nil {
+		return DetectResult{}, err
+	}
+	return c.detect(licenseContent, options)
+}
+
+// DetectRegions finds all licenses in content, e.g. a notice file that concatenates licenses of several dependencies.
+// Every license comes with its byte range in content and its confidence. Parts of content that aren't any license are returned as unmatched spans.
+func DetectRegions(licenseContent []byte) (RegionsResult, error) {
+	c, err := loadCorpus()
+	if err != nil {
+		return RegionsResult{}, err
+	}
+	return c.detectRegions(licenseContent)
 }
 
 func DetectFromPath(localPath string) (LicenseInfo, error) {
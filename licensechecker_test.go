@@ -0,0 +1,205 @@
+package licensechecker
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ledongthuc/licensechecker/internal/data"
+)
+
+const exampleMITContent = `MIT License
+
+Copyright (c) 2019 Thuc Le
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the "Software"), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+The above copyright notice and this permission notice shall be included in all
+copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+`
+
+// exampleCosmeticMITContent differs from MIT license only by spelling, quotes, bullets and copyright notice
+const exampleCosmeticMITContent = `MIT Licence
+
+© 2015-2019 Thuc Le <thuc@example.com>
+All rights reserved.
+
+Permission is hereby granted, free of charge, to any person obtaining a copy
+of this software and associated documentation files (the “Software”), to deal
+in the Software without restriction, including without limitation the rights
+to use, copy, modify, merge, publish, distribute, sub-licence, and/or sell
+copies of the Software, and to permit persons to whom the Software is
+furnished to do so, subject to the following conditions:
+
+  * The above copyright notice and this permission notice shall be included in
+    all copies or substantial portions of the Software.
+
+THE SOFTWARE IS PROVIDED “AS IS”, WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+SOFTWARE.
+`
+
+func TestDetect(t *testing.T) {
+	content0BSD, _ := data.Asset("0BSD.txt")
+	contentLibtool, _ := data.Asset("Libtool-exception.txt")
+
+	tests := []struct {
+		name      string
+		content   []byte
+		licenseID string
+		wantErr   error
+	}{
+		{
+			name:      "Verbatim standard license",
+			content:   content0BSD,
+			licenseID: "0BSD",
+		},
+		{
+			name:      "Verbatim exception license",
+			content:   contentLibtool,
+			licenseID: "Libtool-exception",
+		},
+		{
+			name:      "License with real copyright holder",
+			content:   []byte(exampleMITContent),
+			licenseID: "MIT",
+		},
+		{
+			name:      "License in Markdown",
+			content:   []byte(strings.Replace(exampleMITContent, "MIT License", "# [MIT License](https://opensource.org/licenses/MIT)", 1)),
+			licenseID: "MIT",
+		},
+		{
+			name:    "Empty content",
+			content: []byte{},
+			wantErr: ErrorNoMatch,
+		},
+		{
+			name:    "Not a license",
+			content: []byte("Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore."),
+			wantErr: ErrorNoMatch,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Detect(tt.content)
+			if err != tt.wantErr {
+				t.Errorf("Detect() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got.LicenseID != tt.licenseID {
+				t.Errorf("Detect() = %v, want %v", got.LicenseID, tt.licenseID)
+			}
+		})
+	}
+}
+
+func TestDetectWithOptions(t *testing.T) {
+	content0BSD, _ := data.Asset("0BSD.txt")
+	readme := "# Project\n\nA tool for checking licenses of dependencies, it supports many sources and formats.\n\n## License\n\n" + exampleMITContent
+
+	tests := []struct {
+		name               string
+		content            []byte
+		options            DetectOptions
+		licenseID          string
+		minConfidence      float64
+		minCoverage        float64
+		maxCoverage        float64
+		numberOfCandidates int
+		variables          map[string]string
+		wantErr            error
+	}{
+		{
+			name:          "Verbatim license",
+			content:       content0BSD,
+			licenseID:     "0BSD",
+			minConfidence: 1,
+			minCoverage:   1,
+			maxCoverage:   1,
+		},
+		{
+			name:          "Cosmetic differences are normalized",
+			content:       []byte(exampleCosmeticMITContent),
+			licenseID:     "MIT",
+			minConfidence: 1,
+			minCoverage:   1,
+			maxCoverage:   1,
+		},
+		{
+			name:          "License template captures variables",
+			content:       []byte(exampleMITContent),
+			licenseID:     "MIT",
+			minConfidence: 1,
+			minCoverage:   1,
+			maxCoverage:   1,
+			variables:     map[string]string{"copyright": "Copyright (c) 2019 Thuc Le"},
+		},
+		{
+			name:               "License inside other content",
+			content:            []byte(readme),
+			options:            DetectOptions{NumberOfCandidates: 3},
+			licenseID:          "MIT",
+			minConfidence:      0.9,
+			minCoverage:        0.5,
+			maxCoverage:        0.99,
+			numberOfCandidates: 3,
+		},
+		{
+			name:               "Not a license",
+			content:            []byte("Lorem ipsum dolor sit amet, consectetur adipiscing elit, sed do eiusmod tempor incididunt ut labore."),
+			options:            DetectOptions{NumberOfCandidates: 2},
+			numberOfCandidates: 0,
+			wantErr:            ErrorNoMatch,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DetectWithOptions(tt.content, tt.options)
+			if err != tt.wantErr {
+				t.Errorf("DetectWithOptions() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got.LicenseID != tt.licenseID {
+				t.Errorf("DetectWithOptions() = %v, want %v", got.LicenseID, tt.licenseID)
+			}
+			if got.Confidence < tt.minConfidence {
+				t.Errorf("DetectWithOptions() confidence = %v, want from %v", got.Confidence, tt.minConfidence)
+			}
+			if got.Coverage < tt.minCoverage || got.Coverage > tt.maxCoverage {
+				t.Errorf("DetectWithOptions() coverage = %v, want from %v to %v", got.Coverage, tt.minCoverage, tt.maxCoverage)
+			}
+			if tt.variables != nil && !reflect.DeepEqual(got.Variables, tt.variables) {
+				t.Errorf("DetectWithOptions() variables = %v, want %v", got.Variables, tt.variables)
+			}
+			if len(got.Candidates) != tt.numberOfCandidates {
+				t.Errorf("DetectWithOptions() number of candidates = %v, want %v", len(got.Candidates), tt.numberOfCandidates)
+			}
+			for index, candidate := range got.Candidates {
+				if candidate.Confidence > got.Confidence && got.LicenseID != "" {
+					t.Errorf("DetectWithOptions() candidate %s has higher confidence than the best match", candidate.LicenseID)
+				}
+				if index > 0 && candidate.Confidence > got.Candidates[index-1].Confidence {
+					t.Errorf("DetectWithOptions() candidates aren't ordered by confidence")
+				}
+			}
+		})
+	}
+}
@@ -4,16 +4,18 @@ import (
 	"fmt"
 	"net/http"
 	"os"
+	"path"
 	"regexp"
 	"strings"
 
 	"github.com/parnurzeal/gorequest"
-
-	"github.com/ledongthuc/licensechecker/template"
 )
 
 const OUTPUT = "%q,%q,%q\n"
 
+// UnknownLicenseName is returned by GetLicenseName when content doesn't match any license
+const UnknownLicenseName = "Unknown license"
+
 type URLType int
 
 const (
@@ -144,7 +146,11 @@ func main() {
 					continue
 				}
 
-				fmt.Printf(OUTPUT, config.GetName(url), GetLicenseName(string(body)), licenseURL)
+				name := GetLicenseName(string(body))
+				if isReadme(licenseURL) {
+					name = GetReadmeLicenseName(string(body), path.Base(licenseURL))
+				}
+				fmt.Printf(OUTPUT, config.GetName(url), name, licenseURL)
 				goto NextURL
 			}
 		}
@@ -155,24 +161,44 @@ func main() {
 	return
 }
 
+// GetLicenseName detects license of content by DefaultChain and returns its name. An SPDX tag with an expression of
+// several licenses is returned as the expression.
 func GetLicenseName(license string) string {
-	if strings.Contains(strings.ToLower(string(license)), strings.ToLower("MIT license")) {
-		return "MIT license"
+	result, err := DefaultChain().Detect([]byte(license))
+	if err == nil && result.LicenseID == "" {
+		return result.Expression
 	}
-	if strings.Contains(strings.ToLower(string(license)), strings.ToLower("Mozilla Public License")) {
-		return "Mozilla Public License"
+	if err == nil && result.Exception.LicenseID != "" {
+		return resultName(result) + " with " + result.Exception.Name
 	}
-	if strings.Contains(strings.ToLower(string(license)), strings.ToLower("Apache License")) && strings.Contains(strings.ToLower(string(license)), strings.ToLower("Version 2.0")) {
-		return "Apache License 2.0"
+	if err == nil {
+		return resultName(result)
 	}
 
-	name, distance := template.FindMatchName(license)
-	if distance < 500 {
-		return name
+	// Copyright statements are kept out of license name, they're available with ExtractCopyrights
+	return UnknownLicenseName
+}
+
+// GetReadmeLicenseName finds license of README by its license section, badges and phrases. The name is marked
+// as it comes from README, which is less reliable than a license file.
+func GetReadmeLicenseName(readme, fileName string) string {
+	result, err := DetectReadme([]byte(readme), fileName)
+	if err != nil {
+		return UnknownLicenseName
 	}
+	return resultName(result.DetectResult) + " (from README)"
+}
+
+// resultName is name of detected license. Names of "-only" variants end with "only", which isn't known when scope is ambiguous.
+func resultName(result DetectResult) string {
+	if result.Scope == ScopeAmbiguous {
+		return strings.TrimSuffix(result.Name, " only")
+	}
+	return result.Name
+}
 
-	re := regexp.MustCompile(`(?i)Copyright(.+)`)
-	return re.FindString(license)
+func isReadme(licenseURL string) bool {
+	return strings.HasPrefix(strings.ToLower(path.Base(licenseURL)), "readme")
 }
 
 func ReplaceLisenceURL(config Config, licenseURL, url string) string {
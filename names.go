@@ -0,0 +1,89 @@
+package licensechecker
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	nameSeparatorPattern = regexp.MustCompile(`[^\p{L}\p{N}.+]+`)
+	nameVersionPattern   = regexp.MustCompile(`^([\p{L}]*?)v(\d)`)
+	nameDigitPattern     = regexp.MustCompile(`\d`)
+)
+
+// nameNoiseWords are skipped from license names, so "the MIT License" and "MIT" have the same key
+var nameNoiseWords = map[string]struct{}{
+	"the":     {},
+	"license": {},
+	"licence": {},
+	"version": {},
+}
+
+// newNameIndex maps keys of IDs and names of licenses into their entries. Exceptions are skipped, and
+// deprecated licenses don't replace other licenses with the same key.
+func newNameIndex(entries []corpusEntry) map[string]int {
+	names := make(map[string]int)
+	add := func(key string, entryIndex int) {
+		if key == "" {
+			return
+		}
+		if existing, ok := names[key]; ok && !entries[existing].info.IsDeprecated {
+			return
+		}
+		names[key] = entryIndex
+	}
+	for entryIndex, entry := range entries {
+		if entry.exception {
+			continue
+		}
+		add(nameKey(entry.info.LicenseID), entryIndex)
+		add(nameKey(entry.info.Name), entryIndex)
+	}
+	return names
+}
+
+// nameKey normalizes license ID or name for lookup. Case, punctuation, noise words and "v" of versions are ignored,
+// a version that follows its name is a word, e.g. "GPLv2" is "gpl 2".
+func nameKey(name string) string {
+	words := nameSeparatorPattern.Split(strings.ToLower(name), -1)
+	result := make([]string, 0, len(words))
+	for _, word := range words {
+		word = strings.Trim(word, ".")
+		if _, ok := nameNoiseWords[word]; ok || word == "" {
+			continue
+		}
+		if match := nameVersionPattern.FindStringSubmatchIndex(word); match != nil {
+			if match[3] > match[2] {
+				result = append(result, word[match[2]:match[3]])
+			}
+			word = word[match[4]:]
+		}
+		result = append(result, word)
+	}
+	return strings.Join(result, " ")
+}
+
+// isSpecificName checks whether name found in prose is a license name instead of an ordinary word. It must have 2 words
+// or more, a version, or be the license ID as is, e.g. "Fair License", "GPLv2" and "MIT" but not "fair".
+func isSpecificName(name string, info LicenseInfo) bool {
+	words := 0
+	for _, word := range nameSeparatorPattern.Split(strings.ToLower(name), -1) {
+		if word != "" && word != "the" {
+			words++
+		}
+	}
+	return words >= 2 || nameDigitPattern.MatchString(name) || name == info.LicenseID
+}
+
+// findByName looks up a license by its ID or name in free text, e.g. "Apache License, Version 2.0" or "apache-2.0".
+// A bare major version also matches ".0" version, e.g. "Apache 2" is "Apache-2.0".
+func (c corpus) findByName(name string) (LicenseInfo, bool) {
+	key := nameKey(name)
+	if entryIndex, ok := c.names[key]; ok {
+		return c.entries[entryIndex].info, true
+	}
+	if entryIndex, ok := c.names[key+".0"]; ok {
+		return c.entries[entryIndex].info, true
+	}
+	return LicenseInfo{}, false
+}
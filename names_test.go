@@ -0,0 +1,77 @@
+package licensechecker
+
+import (
+	"testing"
+)
+
+func Test_nameKey(t *testing.T) {
+	tests := []struct {
+		name string
+		want string
+	}{
+		{name: "Apache-2.0", want: "apache 2.0"},
+		{name: "Apache License, Version 2.0", want: "apache 2.0"},
+		{name: "the MIT License", want: "mit"},
+		{name: "GPLv2+", want: "gpl 2+"},
+		{name: "LGPLv3", want: "lgpl 3"},
+		{name: "GPL v2", want: "gpl 2"},
+		{name: "GNU General Public License v3.0 only", want: "gnu general public 3.0 only"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nameKey(tt.name); got != tt.want {
+				t.Errorf("nameKey() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_corpus_findByName(t *testing.T) {
+	c, err := loadCorpus()
+	if err != nil {
+		t.Errorf("loadCorpus() error = %v", err)
+		return
+	}
+	tests := []struct {
+		name      string
+		licenseID string
+		found     bool
+	}{
+		{name: "mit", licenseID: "MIT", found: true},
+		{name: "Apache 2", licenseID: "Apache-2.0", found: true},
+		{name: "BSD 3-Clause \"New\" or \"Revised\" License", licenseID: "BSD-3-Clause", found: true},
+		{name: "Classpath-exception-2.0", found: false},
+		{name: "Unknown License", found: false},
+		{name: "LGPLv3", licenseID: "LGPL-3.0", found: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, found := c.findByName(tt.name)
+			if found != tt.found || got.LicenseID != tt.licenseID {
+				t.Errorf("findByName() = %v, %v, want %v, %v", got.LicenseID, found, tt.licenseID, tt.found)
+			}
+		})
+	}
+}
+
+func Test_isSpecificName(t *testing.T) {
+	tests := []struct {
+		name      string
+		licenseID string
+		want      bool
+	}{
+		{name: "Fair License", licenseID: "Fair", want: true},
+		{name: "fair", licenseID: "Fair", want: false},
+		{name: "the fair", licenseID: "Fair", want: false},
+		{name: "GPLv2", licenseID: "GPL-2.0", want: true},
+		{name: "MIT", licenseID: "MIT", want: true},
+		{name: "mit", licenseID: "MIT", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isSpecificName(tt.name, LicenseInfo{LicenseID: tt.licenseID}); got != tt.want {
+				t.Errorf("isSpecificName() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
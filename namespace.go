@@ -0,0 +1,113 @@
+package licensechecker
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrorNotFound    = errors.New("Can't find license by the ID")
+	ErrorUnknownKind = errors.New("Kind must be KindLicense or KindException")
+)
+
+// Namespace is a collection of SPDX entries of one kind, licenses or exceptions. Licenses and exceptions are kept in
+// their own namespaces, so an exception can't replace a license with the same ID. A namespace isn't changed after it's
+// created, so it's safe for concurrent use.
+type Namespace struct {
+	Kind Kind
+	// infos are ordered by ID
+	infos []LicenseInfo
+	// ids maps lower case IDs into infos
+	ids map[string]int
+}
+
+// newNamespace creates namespace of kind from a map of converted entries, see convertStandardLicenses
+func newNamespace(kind Kind, container map[string]LicenseInfo) Namespace {
+	n := Namespace{
+		Kind:  kind,
+		infos: make([]LicenseInfo, 0, len(container)),
+		ids:   make(map[string]int, len(container)),
+	}
+	for _, info := range container {
+		n.infos = append(n.infos, info)
+	}
+	sort.Slice(n.infos, func(i, j int) bool {
+		return n.infos[i].LicenseID < n.infos[j].LicenseID
+	})
+	for index, info := range n.infos {
+		n.ids[strings.ToLower(info.LicenseID)] = index
+	}
+	return n
+}
+
+// Licenses returns namespace of standard licenses of the catalog
+func Licenses() (Namespace, error) {
+	c, err := LoadCatalog()
+	if err != nil {
+		return Namespace{}, err
+	}
+	return c.licenses, nil
+}
+
+// Exceptions returns namespace of license exceptions of the catalog
+func Exceptions() (Namespace, error) {
+	c, err := LoadCatalog()
+	if err != nil {
+		return Namespace{}, err
+	}
+	return c.exceptions, nil
+}
+
+// Get looks up an entry of namespace by its ID case-insensitively
+func (n Namespace) Get(licenseID string) (LicenseInfo, bool) {
+	index, ok := n.ids[strings.ToLower(licenseID)]
+	if !ok {
+		return LicenseInfo{}, false
+	}
+	return n.infos[index].clone(), true
+}
+
+// List returns all entries of namespace ordered by ID
+func (n Namespace) List() []LicenseInfo {
+	infos := make([]LicenseInfo, 0, len(n.infos))
+	for _, info := range n.infos {
+		infos = append(infos, info.clone())
+	}
+	return infos
+}
+
+// Load loads an entry of namespace with its content, it returns ErrorNotFound if namespace doesn't have the ID
+func (n Namespace) Load(licenseID string) (License, error) {
+	info, ok := n.Get(licenseID)
+	if !ok {
+		return License{}, errors.Wrap(ErrorNotFound, "Error when load '"+licenseID+"' of "+n.Kind.String()+"s")
+	}
+	return GetByInfo(info)
+}
+
+// loadNamespaces loads namespaces of kinds in order, licenses then exceptions if kinds are empty
+func loadNamespaces(kinds []Kind) ([]Namespace, error) {
+	if len(kinds) == 0 {
+		kinds = []Kind{KindLicense, KindException}
+	}
+	namespaces := make([]Namespace, 0, len(kinds))
+	for _, kind := range kinds {
+		var n Namespace
+		var err error
+		switch kind {
+		case KindLicense:
+			n, err = Licenses()
+		case KindException:
+			n, err = Exceptions()
+		default:
+			err = ErrorUnknownKind
+		}
+		if err != nil {
+			return nil, err
+		}
+		namespaces = append(namespaces, n)
+	}
+	return namespaces, nil
+}
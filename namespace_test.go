@@ -0,0 +1,111 @@
+package licensechecker
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func Test_newNamespace(t *testing.T) {
+	licenses := newNamespace(KindLicense, map[string]LicenseInfo{
+		"Zlib": {LicenseID: "Zlib", Kind: KindLicense},
+		"Foo":  {LicenseID: "Foo", Name: "Foo License", Kind: KindLicense},
+	})
+	exceptions := newNamespace(KindException, map[string]LicenseInfo{
+		"Foo": {LicenseID: "Foo", Name: "Foo Exception", Kind: KindException},
+	})
+
+	if got := licenses.List(); len(got) != 2 || got[0].LicenseID != "Foo" || got[1].LicenseID != "Zlib" {
+		t.Errorf("List() = %v, want Foo and Zlib", got)
+	}
+	if got, ok := licenses.Get("Foo"); !ok || got.Name != "Foo License" {
+		t.Errorf("Get() of licenses = %v, %v, want Foo License", got.Name, ok)
+	}
+	if got, ok := exceptions.Get("Foo"); !ok || got.Name != "Foo Exception" {
+		t.Errorf("Get() of exceptions = %v, %v, want Foo Exception", got.Name, ok)
+	}
+	if _, ok := exceptions.Get("Zlib"); ok {
+		t.Errorf("Get() of exceptions finds a license")
+	}
+}
+
+func TestNamespaces(t *testing.T) {
+	licenses, err := Licenses()
+	if err != nil {
+		t.Errorf("Licenses() error = %v", err)
+		return
+	}
+	exceptions, err := Exceptions()
+	if err != nil {
+		t.Errorf("Exceptions() error = %v", err)
+		return
+	}
+	tests := []struct {
+		name      string
+		namespace Namespace
+		licenseID string
+		kind      Kind
+		wantErr   error
+	}{
+		{name: "License", namespace: licenses, licenseID: "MIT", kind: KindLicense},
+		{name: "Exception", namespace: exceptions, licenseID: "Classpath-exception-2.0", kind: KindException},
+		{name: "Exception of licenses", namespace: licenses, licenseID: "Classpath-exception-2.0", wantErr: ErrorNotFound},
+		{name: "License of exceptions", namespace: exceptions, licenseID: "MIT", wantErr: ErrorNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.namespace.Load(tt.licenseID)
+			if errors.Cause(err) != tt.wantErr {
+				t.Errorf("Load() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err == nil && (got.Kind != tt.kind || got.LicenseContent.LicenseID != tt.licenseID || len(got.Content) == 0) {
+				t.Errorf("Load() = %v of %v, want %v of %v with content", got.LicenseInfo.LicenseID, got.Kind, tt.licenseID, tt.kind)
+			}
+		})
+	}
+}
+
+func TestAllInfo_kinds(t *testing.T) {
+	licenses, _ := Licenses()
+	exceptions, _ := Exceptions()
+	tests := []struct {
+		name    string
+		kinds   []Kind
+		want    int
+		wantErr error
+	}{
+		{name: "Both", want: len(licenses.List()) + len(exceptions.List())},
+		{name: "Licenses", kinds: []Kind{KindLicense}, want: len(licenses.List())},
+		{name: "Exceptions", kinds: []Kind{KindException}, want: len(exceptions.List())},
+		{name: "Unknown", kinds: []Kind{KindUnknown}, wantErr: ErrorUnknownKind},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := AllInfo(tt.kinds...)
+			if err != tt.wantErr {
+				t.Errorf("AllInfo() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if len(got) != tt.want {
+				t.Errorf("AllInfo() has %d entries, want %d", len(got), tt.want)
+			}
+			for _, info := range got {
+				if len(tt.kinds) == 1 && info.Kind != tt.kinds[0] {
+					t.Errorf("AllInfo() has %v of %v, want only %v", info.LicenseID, info.Kind, tt.kinds[0])
+				}
+			}
+		})
+	}
+}
+
+func TestSearchByName_kinds(t *testing.T) {
+	got, err := SearchByName("libtool", false, KindLicense)
+	if err != nil || len(got) != 0 {
+		t.Errorf("SearchByName() of licenses = %d entries, error = %v, want none", len(got), err)
+	}
+	got, err = SearchByName("libtool", false, KindException)
+	if err != nil || len(got) != 1 || got[0].LicenseInfo.LicenseID != "Libtool-exception" {
+		t.Errorf("SearchByName() of exceptions = %d entries, error = %v, want Libtool-exception", len(got), err)
+	}
+}
@@ -0,0 +1,13 @@
+package normalize
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+)
+
+// Fingerprint is SHA-256 hash of normalized words, verbatim copies of a license text have the same fingerprint
+func Fingerprint(words []string) string {
+	sum := sha256.Sum256([]byte(strings.Join(words, " ")))
+	return hex.EncodeToString(sum[:])
+}
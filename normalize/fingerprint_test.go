@@ -0,0 +1,25 @@
+package normalize
+
+import (
+	"testing"
+)
+
+func TestFingerprint(t *testing.T) {
+	original := Fingerprint(Words("MIT License\n\nCopyright (c) <year> <copyright holders>\n\nPermission is hereby granted"))
+	tests := []struct {
+		name    string
+		content string
+		same    bool
+	}{
+		{name: "Copyright, case and whitespace", content: "mit license\nCopyright 2019 Thuc Le\n  Permission is  hereby granted", same: true},
+		{name: "Equivalent words", content: "MIT Licence\n\nPermission is hereby granted", same: true},
+		{name: "Other words", content: "MIT License\n\nPermission is granted", same: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if same := Fingerprint(Words(tt.content)) == original; same != tt.same {
+				t.Errorf("Fingerprint() is same = %v, want %v", same, tt.same)
+			}
+		})
+	}
+}
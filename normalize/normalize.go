@@ -0,0 +1,232 @@
+// Package normalize prepares license texts for comparison, following the SPDX License Matching Guidelines
+// (https://spdx.org/spdx-license-list/matching-guidelines).
+package normalize
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// romanNumerals are list numbers from ii to xx, single letters are covered as letters
+const romanNumerals = `(?:i{2,3}|iv|vi{1,3}|ix|xi{1,3}|xiv|xvi{0,3}|xix|xx)`
+
+var (
+	// lineBreakPattern covers line endings of unix, windows and old mac
+	lineBreakPattern = regexp.MustCompile(`\r\n|\r|\n`)
+	// bulletPattern matches bullets and list numbers at beginning of line, e.g. "*", "-", "1.", "1.2)", "(a)", "iv.".
+	// Roman numerals are i to xx, so a word like "civil." isn't a bullet.
+	bulletPattern = regexp.MustCompile(`(?i)^(?:[*•·‣◦\-–—+>]|\(?(?:\d{1,3}(?:\.\d{1,3})*|[a-z]|` + romanNumerals + `)[.)]|\((?:\d{1,3}|[a-z]|` + romanNumerals + `)\))\s+`)
+	// copyrightSymbol matches "©", "copyright (c)", and "(c)" before a year, other "(c)" are list numbers, e.g. "4(c)"
+	copyrightSymbol  = regexp.MustCompile(`(?i)(?:copyright\s*)?©|copyright\s*\(c\)|\(c\)\s*((?:19|20)\d{2}\b)`)
+	quotePattern     = regexp.MustCompile("[\"'`´‘’‚‛“”„‟«»‹›]+")
+	dashPattern      = regexp.MustCompile(`[‐‑‒–—―−]|-{2,}`)
+	urlSchemePattern = regexp.MustCompile(`https?://`)
+	spacePattern     = regexp.MustCompile(`\s+`)
+	wordPattern      = regexp.MustCompile(`[\p{L}\p{N}]+`)
+)
+
+// equivalentWords maps word variants into their canonical spelling, base on equivalent words list of SPDX
+var equivalentWords = map[string]string{
+	"acknowledgment":   "acknowledgement",
+	"analogue":         "analog",
+	"analyse":          "analyze",
+	"artefact":         "artifact",
+	"authorisation":    "authorization",
+	"authorised":       "authorized",
+	"calibre":          "caliber",
+	"cancelled":        "canceled",
+	"capitalisations":  "capitalizations",
+	"catalogue":        "catalog",
+	"categorise":       "categorize",
+	"centre":           "center",
+	"emphasised":       "emphasized",
+	"favour":           "favor",
+	"favourite":        "favorite",
+	"fulfil":           "fulfill",
+	"fulfilment":       "fulfillment",
+	"initialise":       "initialize",
+	"judgment":         "judgement",
+	"labelling":        "labeling",
+	"labour":           "labor",
+	"licence":          "license",
+	"licences":         "licenses",
+	"licenced":         "licensed",
+	"licencing":        "licensing",
+	"maximise":         "maximize",
+	"modelled":         "modeled",
+	"modelling":        "modeling",
+	"offence":          "offense",
+	"optimise":         "optimize",
+	"organisation":     "organization",
+	"organise":         "organize",
+	"practise":         "practice",
+	"programme":        "program",
+	"realise":          "realize",
+	"recognise":        "recognize",
+	"signalling":       "signaling",
+	"sublicence":       "sublicense",
+	"utilisation":      "utilization",
+	"whilst":           "while",
+	"wilful":           "willful",
+	"noncommercial":    "non-commercial",
+	"per cent":         "percent",
+	"copyright owner":  "copyright holder",
+	"sub-license":      "sublicense",
+	"sub license":      "sublicense",
+	"sub-licence":      "sublicense",
+	"sub licence":      "sublicense",
+	"non commercial":   "non-commercial",
+	"copyright owners": "copyright holders",
+}
+
+var equivalentWordsPattern = compileEquivalentWords()
+
+// compileEquivalentWords builds one pattern for all equivalent words, longer variants go first so they win over their prefix
+func compileEquivalentWords() *regexp.Regexp {
+	variants := make([]string, 0, len(equivalentWords))
+	for variant := range equivalentWords {
+		variants = append(variants, variant)
+	}
+	sort.Slice(variants, func(i, j int) bool {
+		if len(variants[i]) != len(variants[j]) {
+			return len(variants[i]) > len(variants[j])
+		}
+		return variants[i] < variants[j]
+	})
+	for index, variant := range variants {
+		variants[index] = strings.Replace(regexp.QuoteMeta(variant), " ", `\s+`, -1)
+	}
+	return regexp.MustCompile(`(?i)\b(?:` + strings.Join(variants, "|") + `)\b`)
+}
+
+// Step is a normalization rule of SPDX matching guidelines. Steps can be combined as bit flags.
+type Step int
+
+const (
+	// StepCopyrightNotice removes copyright statements of the leading notice block
+	StepCopyrightNotice Step = 1 << iota
+	// StepBullets removes bullets and list numbers at beginning of lines
+	StepBullets
+	// StepCase converts text into lower case
+	StepCase
+	// StepCopyrightSymbol unifies "©", "(c)" before a year and "copyright (c)" into "copyright"
+	StepCopyrightSymbol
+	// StepPunctuation unifies quote and dash variants
+	StepPunctuation
+	// StepURLScheme unifies "http://" and "https://"
+	StepURLScheme
+	// StepWhitespace collapses all whitespace into one space
+	StepWhitespace
+	// StepEquivalentWords replaces word variants by their canonical spelling, e.g. "licence" to "license"
+	StepEquivalentWords
+
+	// AllSteps contains all normalization steps
+	AllSteps = StepCopyrightNotice | StepBullets | StepCase | StepCopyrightSymbol |
+		StepPunctuation | StepURLScheme | StepWhitespace | StepEquivalentWords
+)
+
+var stepNames = []struct {
+	step Step
+	name string
+}{
+	{StepCopyrightNotice, "copyright-notice"},
+	{StepBullets, "bullets"},
+	{StepCase, "case"},
+	{StepCopyrightSymbol, "copyright-symbol"},
+	{StepPunctuation, "punctuation"},
+	{StepURLScheme, "url-scheme"},
+	{StepWhitespace, "whitespace"},
+	{StepEquivalentWords, "equivalent-words"},
+}
+
+// Names returns names of steps that are included, in the order they're applied
+func (s Step) Names() []string {
+	names := []string{}
+	for _, item := range stepNames {
+		if s&item.step != 0 {
+			names = append(names, item.name)
+		}
+	}
+	return names
+}
+
+// Text normalizes license text into one lower case line with all steps. It removes copyright notice lines, bullets and
+// list numbers, unifies copyright symbols, quotes, dashes, url schemes and equivalent words, then collapses whitespace.
+func Text(content string) string {
+	return TextWithSteps(content, AllSteps)
+}
+
+// TextWithSteps normalizes license text with selected steps only
+func TextWithSteps(content string, steps Step) string {
+	text := content
+	if steps&(StepCopyrightNotice|StepBullets) != 0 {
+		text = normalizeLines(text, steps)
+	}
+	if steps&StepCase != 0 {
+		text = strings.ToLower(text)
+	}
+	if steps&StepCopyrightSymbol != 0 {
+		text = copyrightSymbol.ReplaceAllStringFunc(text, func(symbol string) string {
+			// A year that follows "(c)" is kept as a separated word
+			if year := copyrightSymbol.FindStringSubmatch(symbol)[1]; year != "" {
+				return "copyright " + year
+			}
+			return "copyright"
+		})
+	}
+	if steps&StepPunctuation != 0 {
+		text = quotePattern.ReplaceAllString(text, "'")
+		text = dashPattern.ReplaceAllString(text, "-")
+	}
+	if steps&StepURLScheme != 0 {
+		text = urlSchemePattern.ReplaceAllString(text, "http://")
+	}
+	if steps&StepWhitespace != 0 {
+		text = strings.TrimSpace(spacePattern.ReplaceAllString(text, " "))
+	}
+	if steps&StepEquivalentWords != 0 {
+		text = equivalentWordsPattern.ReplaceAllStringFunc(text, func(variant string) string {
+			return equivalentWords[strings.ToLower(spacePattern.ReplaceAllString(variant, " "))]
+		})
+	}
+	return text
+}
+
+// normalizeLines removes copyright notices of the leading notice block and bullets line by line, line breaks are kept
+func normalizeLines(content string, steps Step) string {
+	lines := lineBreakPattern.Split(content, -1)
+	kept := make([]string, 0, len(lines))
+	notices := noticeBlock{}
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if steps&StepCopyrightNotice != 0 && notices.skip(line) {
+			continue
+		}
+		for steps&StepBullets != 0 {
+			stripped := bulletPattern.ReplaceAllString(line, "")
+			if stripped == line {
+				break
+			}
+			line = stripped
+		}
+		if line == "" || steps&StepCopyrightNotice != 0 && notices.skip(line) {
+			continue
+		}
+		kept = append(kept, line)
+	}
+	return strings.Join(kept, "\n")
+}
+
+// Words normalizes license text and breaks it into words, punctuation is skipped
+func Words(content string) []string {
+	tokens := Tokenize(content)
+	if len(tokens) == 0 {
+		return nil
+	}
+	words := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		words = append(words, token.Text)
+	}
+	return words
+}
@@ -0,0 +1,113 @@
+package normalize
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestText(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    string
+	}{
+		{
+			name:    "Empty content",
+			content: "",
+			want:    "",
+		},
+		{
+			name:    "Whitespace and case",
+			content: "Permission  is\r\nhereby\tGRANTED,\rfree of charge",
+			want:    "permission is hereby granted, free of charge",
+		},
+		{
+			name:    "Copyright notice lines",
+			content: "MIT License\n\nCopyright (c) 2019 Thuc Le\n© 2020 Someone\n(C) 2021 Other\nAll rights reserved.\nThe above copyright notice",
+			want:    "mit license the above copyright notice",
+		},
+		{
+			name:    "Copyright notice lines after license body are kept",
+			content: "Copyright 2019 Thuc Le\nPermission is hereby granted.\nCopyright 2020 Someone",
+			want:    "permission is hereby granted. copyright 2020 someone",
+		},
+		{
+			name:    "Re-wrapped sentence that starts with copyright notice",
+			content: "Copyright 2019 Thuc Le\n\nThe above\ncopyright notice and this permission notice shall be included",
+			want:    "the above copyright notice and this permission notice shall be included",
+		},
+		{
+			name:    "Copyright symbols inside text",
+			content: "the notice © and (c) and Copyright (C) are kept",
+			want:    "the notice copyright and (c) and copyright are kept",
+		},
+		{
+			name:    "(c) before a year is copyright symbol",
+			content: "clause 4(c) and the (c) 2019 sign",
+			want:    "clause 4(c) and the copyright 2019 sign",
+		},
+		{
+			name:    "Bullets and list numbers",
+			content: "* first\n- second\n1. third\n2.1) fourth\n(a) fifth\niv. sixth\n• seventh\n(xii) eighth",
+			want:    "first second third fourth fifth sixth seventh eighth",
+		},
+		{
+			name:    "Words that look like roman numerals aren't bullets",
+			content: "civil. code\nmix) up\nvi.x",
+			want:    "civil. code mix) up vi.x",
+		},
+		{
+			name:    "Quotes and dashes",
+			content: "“AS IS” ‘quoted’ ``back`` a — b -- c – d",
+			want:    "'as is' 'quoted' 'back' a - b - c - d",
+		},
+		{
+			name:    "Url schemes",
+			content: "see https://spdx.org and http://spdx.org",
+			want:    "see http://spdx.org and http://spdx.org",
+		},
+		{
+			name:    "Equivalent words",
+			content: "This Licence lets the copyright owner sub-license whilst the Organisation is non commercial",
+			want:    "this license lets the copyright holder sublicense while the organization is non-commercial",
+		},
+		{
+			name:    "Equivalent words are whole words only",
+			content: "licensed licences licencee",
+			want:    "licensed licenses licencee",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Text(tt.content); got != tt.want {
+				t.Errorf("Text() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWords(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []string
+	}{
+		{
+			name:    "Empty content",
+			content: "",
+			want:    nil,
+		},
+		{
+			name:    "Punctuation is skipped",
+			content: "Copyright (c) 2019 Thuc Le\n\nTHE SOFTWARE IS PROVIDED \"AS IS\", WITHOUT WARRANTY.",
+			want:    []string{"the", "software", "is", "provided", "as", "is", "without", "warranty"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Words(tt.content); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Words() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
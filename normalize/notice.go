@@ -0,0 +1,48 @@
+package normalize
+
+import (
+	"regexp"
+	"strings"
+)
+
+// maximumTitleWords is the highest number of words of a title line, e.g. "Apache License" or "Version 2.0, January 2004",
+// which can come before copyright notice of a license
+const maximumTitleWords = 10
+
+var (
+	// copyrightStartPattern matches beginning of a copyright statement, "(c)" is a copyright sign only before a year
+	copyrightStartPattern = regexp.MustCompile(`(?i)^(?:copyright\b|©|\(c\)\s*(?:19|20)\d{2}\b)`)
+	// copyrightNotStatementPattern matches sentences of license texts that mention copyright, but aren't statements
+	copyrightNotStatementPattern = regexp.MustCompile(`(?i)^copyright\s+(?:notices?|holders?|owners?|laws?|and|licen[cs]e[sd]?|statements?|interest|disclaimer|protection|of|to|is|in|on|for|this|may|or)\b`)
+	allRightsReservedLinePattern = regexp.MustCompile(`(?i)^all\s+rights\s+reserved\b`)
+	// sentenceEndPattern matches end of a line of license body, titles don't end with punctuation
+	sentenceEndPattern = regexp.MustCompile(`[.,;:]$`)
+)
+
+// IsCopyrightStatement checks whether line starts a copyright statement, e.g. "Copyright 2019 Thuc Le" or "(c) 2019 Thuc
+// Le". Sentences of license texts that start with copyright, e.g. "copyright notice and this permission notice", aren't
+// statements.
+func IsCopyrightStatement(line string) bool {
+	return copyrightStartPattern.MatchString(line) && !copyrightNotStatementPattern.MatchString(line)
+}
+
+// noticeBlock finds the leading notice block of a license: copyright statements and "All rights reserved" lines, which
+// can follow title lines. The block ends at the first line of license body, so a line of body isn't removed when
+// re-wrapping makes it start with "copyright".
+type noticeBlock struct {
+	ended bool
+}
+
+// skip checks whether line is a copyright notice of the leading block
+func (b *noticeBlock) skip(line string) bool {
+	if b.ended {
+		return false
+	}
+	if IsCopyrightStatement(line) || allRightsReservedLinePattern.MatchString(line) {
+		return true
+	}
+	if len(wordPattern.FindAllString(line, maximumTitleWords+1)) > maximumTitleWords || sentenceEndPattern.MatchString(strings.TrimSpace(line)) {
+		b.ended = true
+	}
+	return false
+}
@@ -0,0 +1,145 @@
+package normalize
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var (
+	// tokenPattern matches copyright symbols, url schemes and words, so they are normalized as tokens
+	tokenPattern = regexp.MustCompile(`(?i)(?:copyright\s*)?(?:©|\(c\))|https?://|[\p{L}\p{N}]+`)
+	// yearPattern matches a year after "(c)", which makes it a copyright sign
+	yearPattern = regexp.MustCompile(`^\s*(?:19|20)\d{2}\b`)
+)
+
+// Word is a normalized word with byte offsets [Start, End) of its original text in content
+type Word struct {
+	Text  string
+	Start int
+	End   int
+}
+
+// equivalentPhrase is an equivalent words entry broken into words
+type equivalentPhrase struct {
+	variant   []string
+	canonical []string
+}
+
+// equivalentPhrases are equivalent words grouped by their first word
+var equivalentPhrases = compileEquivalentPhrases()
+
+// compileEquivalentPhrases breaks equivalent words into words, longer variants go first so they win over their prefix
+func compileEquivalentPhrases() map[string][]equivalentPhrase {
+	phrases := make(map[string][]equivalentPhrase)
+	for variant, canonical := range equivalentWords {
+		phrase := equivalentPhrase{
+			variant:   wordPattern.FindAllString(variant, -1),
+			canonical: wordPattern.FindAllString(canonical, -1),
+		}
+		phrases[phrase.variant[0]] = append(phrases[phrase.variant[0]], phrase)
+	}
+	for _, group := range phrases {
+		sort.Slice(group, func(i, j int) bool {
+			return len(group[i].variant) > len(group[j].variant)
+		})
+	}
+	return phrases
+}
+
+// Tokenize normalizes license text with all steps and breaks it into words, which keep offsets of their original text,
+// so matched words can be mapped back into content.
+func Tokenize(content string) []Word {
+	return TokenizeWithSteps(content, AllSteps)
+}
+
+// TokenizeWithSteps is Tokenize with selected steps. Copyright notices and bullets are removed, and words are lower
+// case, only if steps include them. Copyright symbols, url schemes and equivalent words are always unified, so words
+// are the same as words of TextWithSteps.
+func TokenizeWithSteps(content string, steps Step) []Word {
+	words := []Word{}
+	notices := noticeBlock{}
+	lineStart := 0
+	for _, lineBreak := range append(lineBreakPattern.FindAllStringIndex(content, -1), []int{len(content), len(content)}) {
+		line := content[lineStart:lineBreak[0]]
+		offset := lineStart
+		lineStart = lineBreak[1]
+
+		trimmed := strings.TrimLeft(line, " \t\f\v")
+		offset += len(line) - len(trimmed)
+		line = trimmed
+		if steps&StepCopyrightNotice != 0 && notices.skip(line) {
+			continue
+		}
+		for steps&StepBullets != 0 {
+			loc := bulletPattern.FindStringIndex(line)
+			if loc == nil {
+				break
+			}
+			offset += loc[1]
+			line = line[loc[1]:]
+		}
+		if steps&StepCopyrightNotice != 0 && notices.skip(line) {
+			continue
+		}
+
+		for _, loc := range tokenPattern.FindAllStringIndex(line, -1) {
+			token := line[loc[0]:loc[1]]
+			if steps&StepCase != 0 {
+				token = strings.ToLower(token)
+			}
+			switch lower := strings.ToLower(token); {
+			case lower == "(c)" && !yearPattern.MatchString(line[loc[1]:]):
+				// "(c)" without year is a list number, e.g. "4(c)"
+				token = token[1:2]
+				loc = []int{loc[0] + 1, loc[1] - 1}
+			case strings.HasSuffix(token, "©") || strings.HasSuffix(lower, "(c)"):
+				token = "copyright"
+			case urlSchemePattern.MatchString(lower):
+				token = "http"
+			}
+			words = append(words, Word{Text: token, Start: offset + loc[0], End: offset + loc[1]})
+		}
+	}
+	return replaceEquivalentPhrases(words)
+}
+
+// replaceEquivalentPhrases replaces variants of equivalent words by their canonical words. A replaced word covers
+// the whole original text of its variant.
+func replaceEquivalentPhrases(words []Word) []Word {
+	result := make([]Word, 0, len(words))
+	for index := 0; index < len(words); {
+		phrase, ok := findEquivalentPhrase(words[index:])
+		if !ok {
+			result = append(result, words[index])
+			index++
+			continue
+		}
+		start, end := words[index].Start, words[index+len(phrase.variant)-1].End
+		for _, canonical := range phrase.canonical {
+			result = append(result, Word{Text: canonical, Start: start, End: end})
+		}
+		index += len(phrase.variant)
+	}
+	return result
+}
+
+// findEquivalentPhrase finds the longest variant of equivalent words that words start with, case is ignored
+func findEquivalentPhrase(words []Word) (equivalentPhrase, bool) {
+	for _, phrase := range equivalentPhrases[strings.ToLower(words[0].Text)] {
+		if len(phrase.variant) > len(words) {
+			continue
+		}
+		matched := true
+		for index, variant := range phrase.variant {
+			if !strings.EqualFold(words[index].Text, variant) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			return phrase, true
+		}
+	}
+	return equivalentPhrase{}, false
+}
@@ -0,0 +1,89 @@
+package normalize
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []Word
+	}{
+		{
+			name:    "Empty content",
+			content: "",
+			want:    []Word{},
+		},
+		{
+			name:    "Offsets of original text",
+			content: "MIT License\r\n\r\nPermission is",
+			want: []Word{
+				{Text: "mit", Start: 0, End: 3},
+				{Text: "license", Start: 4, End: 11},
+				{Text: "permission", Start: 15, End: 25},
+				{Text: "is", Start: 26, End: 28},
+			},
+		},
+		{
+			name:    "Copyright lines and bullets are skipped, (c) without year is a letter",
+			content: "Copyright (c) 2019 Thuc Le\n  * Redistributions (c) © Copyright (C) https://x",
+			want: []Word{
+				{Text: "redistributions", Start: 31, End: 46},
+				{Text: "c", Start: 48, End: 49},
+				{Text: "copyright", Start: 51, End: 53},
+				{Text: "copyright", Start: 54, End: 67},
+				{Text: "http", Start: 68, End: 76},
+				{Text: "x", Start: 76, End: 77},
+			},
+		},
+		{
+			name:    "Equivalent words cover their variants",
+			content: "Sub-Licence, the copyright owner, noncommercial",
+			want: []Word{
+				{Text: "sublicense", Start: 0, End: 11},
+				{Text: "the", Start: 13, End: 16},
+				{Text: "copyright", Start: 17, End: 32},
+				{Text: "holder", Start: 17, End: 32},
+				{Text: "non", Start: 34, End: 47},
+				{Text: "commercial", Start: 34, End: 47},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Tokenize(tt.content); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Tokenize() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTokenizeWithSteps(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		steps   Step
+		want    []Word
+	}{
+		{
+			name:    "Case and copyright notice are kept, copyright sign joins its word",
+			content: "Copyright (c) 2019 Thuc Le",
+			steps:   AllSteps &^ (StepCopyrightNotice | StepCase),
+			want: []Word{
+				{Text: "copyright", Start: 0, End: 13},
+				{Text: "2019", Start: 14, End: 18},
+				{Text: "Thuc", Start: 19, End: 23},
+				{Text: "Le", Start: 24, End: 26},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := TokenizeWithSteps(tt.content, tt.steps); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TokenizeWithSteps() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package licensechecker
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"io"
+	"unicode/utf16"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// DefaultMaxSize is the highest number of bytes that DetectReader reads if ReaderOptions.MaxSize isn't set
+	DefaultMaxSize = 1 << 20
+	// DecisiveConfidence is the lowest confidence of a match that stops DetectReader before the end of content
+	DecisiveConfidence = 0.99
+	// readerChunkSize is number of bytes that DetectReader reads at once, it's also the first checkpoint of detection
+	readerChunkSize = 64 << 10
+)
+
+var (
+	ErrorTooLarge      = errors.New("Content is larger than the size limit and doesn't have a decisive match")
+	ErrorBinaryContent = errors.New("Content is binary instead of text")
+)
+
+// ReaderOptions are options of DetectReader
+type ReaderOptions struct {
+	DetectOptions
+	// MaxSize is the highest number of bytes that are read, DefaultMaxSize is used if it's 0
+	MaxSize int64
+}
+
+// DetectReader reads content from reader and detects its license. Content is detected at checkpoints that double
+// from readerChunkSize, and reading stops once a match is final, see isFinal. Content that is larger than MaxSize
+// without decisive match in its first MaxSize bytes is refused with ErrorTooLarge, and binary content is refused with
+// ErrorBinaryContent. UTF-16 content with byte order mark is decoded into UTF-8.
+func DetectReader(ctx context.Context, reader io.Reader, options ReaderOptions) (DetectResult, error) {
+	c, err := loadCorpus()
+	if err != nil {
+		return DetectResult{}, err
+	}
+	maxSize := options.MaxSize
+	if maxSize <= 0 {
+		maxSize = DefaultMaxSize
+	}
+
+	content := []byte{}
+	buffer := make([]byte, readerChunkSize)
+	checkpoint := int64(readerChunkSize)
+	for {
+		if err := ctx.Err(); err != nil {
+			return DetectResult{}, errors.Wrap(err, "Error when read content")
+		}
+		n, readErr := reader.Read(buffer)
+		content = append(content, buffer[:n]...)
+		// NUL bytes are binary content, except in UTF-16 text
+		order := utf16Order(content)
+		if order == nil && bytes.IndexByte(buffer[:n], 0) >= 0 {
+			return DetectResult{}, ErrorBinaryContent
+		}
+
+		// Size is checked first, the last read can return data with io.EOF
+		if int64(len(content)) > maxSize {
+			if result, ok := c.detectDecisive(ctx, decodeUTF16(content[:maxSize], order), options.DetectOptions); ok {
+				return result, nil
+			}
+			return DetectResult{}, ErrorTooLarge
+		}
+		if readErr == io.EOF {
+			return c.detect(decodeUTF16(content, order), options.DetectOptions)
+		}
+		if readErr != nil {
+			return DetectResult{}, errors.Wrap(readErr, "Error when read content")
+		}
+		if int64(len(content)) >= checkpoint {
+			checkpoint *= 2
+			if result, ok := c.detectDecisive(ctx, decodeUTF16(content, order), options.DetectOptions); ok && isFinal(result) {
+				return result, nil
+			}
+		}
+	}
+}
+
+// isFinal checks whether a decisive match can't be changed by content after it. Words after the license text make it
+// modified, they can be the start of an exception or a rider, and a notice after it can tell its ambiguous scope.
+func isFinal(result DetectResult) bool {
+	return !result.Modified && result.Scope != ScopeAmbiguous
+}
+
+// utf16Order is byte order of UTF-16 content by its byte order mark, it's nil for other content
+func utf16Order(content []byte) binary.ByteOrder {
+	switch {
+	case bytes.HasPrefix(content, []byte{0xff, 0xfe}):
+		return binary.LittleEndian
+	case bytes.HasPrefix(content, []byte{0xfe, 0xff}):
+		return binary.BigEndian
+	}
+	return nil
+}
+
+// decodeUTF16 converts UTF-16 content after its byte order mark into UTF-8. An odd last byte is skipped, the rest of
+// its code unit isn't read yet. Content is returned as is if order is nil.
+func decodeUTF16(content []byte, order binary.ByteOrder) []byte {
+	if order == nil {
+		return content
+	}
+	units := make([]uint16, 0, len(content)/2)
+	for index := 2; index+1 < len(content); index += 2 {
+		units = append(units, order.Uint16(content[index:]))
+	}
+	return []byte(string(utf16.Decode(units)))
+}
+
+// detectDecisive detects license of content that is read so far, and keeps the result if it's decisive
+func (c corpus) detectDecisive(ctx context.Context, content []byte, options DetectOptions) (DetectResult, bool) {
+	if ctx.Err() != nil {
+		return DetectResult{}, false
+	}
+	result, err := c.detect(content, options)
+	if err != nil || result.Confidence < DecisiveConfidence {
+		return DetectResult{}, false
+	}
+	return result, true
+}
@@ -0,0 +1,119 @@
+package licensechecker
+
+import (
+	"context"
+	"encoding/binary"
+	"io"
+	"strings"
+	"testing"
+	"testing/iotest"
+	"unicode/utf16"
+
+	"github.com/ledongthuc/licensechecker/internal/data"
+	"github.com/pkg/errors"
+)
+
+// endlessReader produces words forever, reading it must stop by limits
+type endlessReader struct{}
+
+func (endlessReader) Read(p []byte) (int, error) {
+	for index := range p {
+		p[index] = "lorem ipsum dolor sit amet\n"[index%27]
+	}
+	return len(p), nil
+}
+
+// encodeUTF16 encodes text into UTF-16 with byte order mark
+func encodeUTF16(text string, order binary.ByteOrder) string {
+	units := append([]uint16{0xfeff}, utf16.Encode([]rune(text))...)
+	content := make([]byte, 2*len(units))
+	for index, unit := range units {
+		order.PutUint16(content[2*index:], unit)
+	}
+	return string(content)
+}
+
+func TestDetectReader(t *testing.T) {
+	contentApache, _ := data.Asset("Apache-2.0.txt")
+	contentLLVM, _ := data.Asset("LLVM-exception.txt")
+	filler := strings.Repeat("lorem ipsum dolor sit amet\n", 4000)
+	tests := []struct {
+		name      string
+		reader    io.Reader
+		options   ReaderOptions
+		licenseID string
+		exception string
+		wantErr   error
+	}{
+		{
+			name:      "Small content",
+			reader:    strings.NewReader(exampleMITContent),
+			licenseID: "MIT",
+		},
+		{
+			name:      "Final match stops reading",
+			reader:    io.MultiReader(strings.NewReader(exampleMITContent+strings.Repeat("\n", 100<<10)), iotest.ErrReader(errors.New("read after final match"))),
+			licenseID: "MIT",
+		},
+		{
+			name:      "Decisive match with text after it at the size limit",
+			reader:    io.MultiReader(strings.NewReader(exampleMITContent+"\n\n"), endlessReader{}),
+			options:   ReaderOptions{MaxSize: 256 << 10},
+			licenseID: "MIT",
+		},
+		{
+			name:    "Too large content",
+			reader:  endlessReader{},
+			options: ReaderOptions{MaxSize: 100 << 10},
+			wantErr: ErrorTooLarge,
+		},
+		{
+			name:    "Too large content ends with the last read",
+			reader:  iotest.DataErrReader(strings.NewReader(filler)),
+			options: ReaderOptions{MaxSize: 100 << 10},
+			wantErr: ErrorTooLarge,
+		},
+		{
+			name:      "Exception after the first checkpoint",
+			reader:    strings.NewReader(string(contentApache) + "\n\n" + filler + "\n\n" + string(contentLLVM)),
+			options:   ReaderOptions{MaxSize: 256 << 10},
+			licenseID: "Apache-2.0",
+			exception: "LLVM-exception",
+		},
+		{
+			name:      "UTF-16 little endian",
+			reader:    strings.NewReader(encodeUTF16(exampleMITContent, binary.LittleEndian)),
+			licenseID: "MIT",
+		},
+		{
+			name:      "UTF-16 big endian",
+			reader:    strings.NewReader(encodeUTF16(exampleMITContent, binary.BigEndian)),
+			licenseID: "MIT",
+		},
+		{
+			name:    "Binary content",
+			reader:  strings.NewReader("\x7fELF\x02\x01\x01\x00\x00"),
+			wantErr: ErrorBinaryContent,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DetectReader(context.Background(), tt.reader, tt.options)
+			if err != tt.wantErr {
+				t.Errorf("DetectReader() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got.LicenseID != tt.licenseID || got.Exception.LicenseID != tt.exception {
+				t.Errorf("DetectReader() = %v with %v, want %v with %v", got.LicenseID, got.Exception.LicenseID, tt.licenseID, tt.exception)
+			}
+		})
+	}
+}
+
+func TestDetectReader_canceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := DetectReader(ctx, endlessReader{}, ReaderOptions{}); errors.Cause(err) != context.Canceled {
+		t.Errorf("DetectReader() error = %v, want %v", err, context.Canceled)
+	}
+}
@@ -0,0 +1,207 @@
+package licensechecker
+
+import (
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/ledongthuc/licensechecker/format"
+)
+
+// maximumPhraseWords is the highest number of words after "released under" that are looked up as license name
+const maximumPhraseWords = 10
+
+var (
+	// atxHeadingPattern matches Markdown headings like "## License"
+	atxHeadingPattern = regexp.MustCompile(`(?m)^[ \t]*(#{1,6})[ \t]+(.*?)[ \t#]*$`)
+	// underlinedHeadingPattern matches Markdown setext and reStructuredText headings, which are underlined by = or -
+	underlinedHeadingPattern = regexp.MustCompile(`(?m)^[ \t]*(\S.*?)[ \t]*\r?\n[ \t]*(={3,}|-{3,}|~{3,}|\^{3,})[ \t]*$`)
+	// htmlHeadingPattern matches HTML headings like "<h2>License</h2>"
+	htmlHeadingPattern = regexp.MustCompile(`(?is)<h([1-6])[^>]*>(.*?)</h[1-6]\s*>`)
+	// licenseHeadingPattern matches heading text of license section
+	licenseHeadingPattern = regexp.MustCompile(`(?i)^\W*(?:copyright\s+(?:and|&)\s+)?licen[cs](?:e|es|ing)\b`)
+	// badgePattern matches shields.io static badges of license, e.g. "https://img.shields.io/badge/license-MIT-blue.svg"
+	badgePattern = regexp.MustCompile(`(?i)img\.shields\.io/badge/licen[cs]e-((?:[^-/?)\s]|--)+)-`)
+	// phrasePattern matches sentences that mention license, e.g. "released under the MIT License", it captures text until end of the clause
+	phrasePattern = regexp.MustCompile(`(?i)\b(?:released|licensed|distributed|available|published)\s+under\s+(?:the\s+)?(?:terms\s+of\s+(?:the\s+)?)?([^;:()\[\]\n]+?)\s*(?:[;:()\[\]\n]|\.(?:\s|$)|$)`)
+)
+
+// ReadmeEvidence tells what a README result is based on
+type ReadmeEvidence int
+
+const (
+	// ReadmeSection is license text found in the license section of README
+	ReadmeSection ReadmeEvidence = iota
+	// ReadmeBadge is a license badge of shields.io
+	ReadmeBadge
+	// ReadmePhrase is a sentence that mentions license, e.g. "released under the MIT License"
+	ReadmePhrase
+)
+
+// ReadmeResult is license that is found in a README file
+type ReadmeResult struct {
+	DetectResult
+	Evidence ReadmeEvidence
+	// Section is byte range of the license section in README, it's empty if README doesn't have any
+	Section Span
+	// LowTrust labels the result as derived from README. README only mentions license of project and can be outdated,
+	// so it's a weaker signal than a license file.
+	LowTrust bool
+}
+
+// DetectReadme finds the license section of README by its heading, and detects license from the section alone.
+// If the section doesn't contain a license text, license badges and phrases like "released under the MIT License" are
+// recognized, in the section first, then in whole README.
+func DetectReadme(content []byte, fileName string) (ReadmeResult, error) {
+	c, err := loadCorpus()
+	if err != nil {
+		return ReadmeResult{}, err
+	}
+
+	result := ReadmeResult{LowTrust: true}
+	searched := [][]byte{}
+	if start, end, ok := findLicenseSection(string(content)); ok {
+		result.Section = Span{Start: start, End: end}
+		section := content[start:end]
+		detected, err := c.detect(section, DetectOptions{Format: format.Sniff(content, fileName)})
+		if err == nil {
+			result.DetectResult = detected
+			result.DetectResult.Deviations = shiftDeviations(detected.Deviations, start)
+			result.Evidence = ReadmeSection
+			return result, nil
+		}
+		searched = append(searched, section)
+	}
+	searched = append(searched, content)
+
+	for _, text := range searched {
+		if info, mention, ok := c.findBadge(string(text)); ok {
+			result.DetectResult = c.mentionedLicense(info, mention)
+			result.Evidence = ReadmeBadge
+			return result, nil
+		}
+		if info, mention, ok := c.findPhrase(string(text)); ok {
+			result.DetectResult = c.mentionedLicense(info, mention)
+			result.Evidence = ReadmePhrase
+			return result, nil
+		}
+	}
+	return result, ErrorNoMatch
+}
+
+// findLicenseSection finds byte range of the section under the first license heading, until the next heading of
+// the same or higher level. Underlined headings are level 1 for "=" and level 2 for others.
+func findLicenseSection(content string) (int, int, bool) {
+	type heading struct {
+		level      int
+		start, end int
+		license    bool
+	}
+	headings := []heading{}
+	for _, loc := range atxHeadingPattern.FindAllStringSubmatchIndex(content, -1) {
+		headings = append(headings, heading{
+			level:   loc[3] - loc[2],
+			start:   loc[0],
+			end:     loc[1],
+			license: licenseHeadingPattern.MatchString(content[loc[4]:loc[5]]),
+		})
+	}
+	for _, loc := range underlinedHeadingPattern.FindAllStringSubmatchIndex(content, -1) {
+		level := 2
+		if content[loc[4]] == '=' {
+			level = 1
+		}
+		headings = append(headings, heading{
+			level:   level,
+			start:   loc[0],
+			end:     loc[1],
+			license: licenseHeadingPattern.MatchString(content[loc[2]:loc[3]]),
+		})
+	}
+	for _, loc := range htmlHeadingPattern.FindAllStringSubmatchIndex(content, -1) {
+		headings = append(headings, heading{
+			level:   int(content[loc[2]] - '0'),
+			start:   loc[0],
+			end:     loc[1],
+			license: licenseHeadingPattern.MatchString(strings.TrimSpace(tagRemover.Replace(content[loc[4]:loc[5]]))),
+		})
+	}
+
+	sort.Slice(headings, func(i, j int) bool {
+		return headings[i].start < headings[j].start
+	})
+	for _, h := range headings {
+		if !h.license {
+			continue
+		}
+		end := len(content)
+		for _, next := range headings {
+			if next.start > h.start && next.start < end && next.level <= h.level {
+				end = next.start
+			}
+		}
+		return h.end, end, true
+	}
+	return 0, 0, false
+}
+
+// tagRemover removes common inline tags of heading text
+var tagRemover = strings.NewReplacer("<b>", "", "</b>", "", "<strong>", "", "</strong>", "", "<em>", "", "</em>", "")
+
+// findBadge looks up license of the first license badge in text, and returns the badge text that mentions it.
+// Badge text is escaped: "--" is "-", "_" is space.
+func (c corpus) findBadge(text string) (LicenseInfo, string, bool) {
+	for _, match := range badgePattern.FindAllStringSubmatch(text, -1) {
+		name, err := url.PathUnescape(match[1])
+		if err != nil {
+			name = match[1]
+		}
+		name = strings.Replace(strings.Replace(name, "--", "-", -1), "_", " ", -1)
+		if info, ok := c.findByName(name); ok {
+			return info, name, true
+		}
+	}
+	return LicenseInfo{}, "", false
+}
+
+// findPhrase looks up license of the first phrase that mentions a known license in text, and returns the clause that
+// mentions it. The longest beginning words of the clause that are a license name win, e.g. "MIT License" of "MIT
+// License, see LICENSE file". Single words without version are only names if they're license IDs, see isSpecificName.
+func (c corpus) findPhrase(text string) (LicenseInfo, string, bool) {
+	for _, match := range phrasePattern.FindAllStringSubmatch(text, -1) {
+		words := strings.Fields(match[1])
+		if len(words) > maximumPhraseWords {
+			words = words[:maximumPhraseWords]
+		}
+		for length := len(words); length > 0; length-- {
+			name := strings.Join(words[:length], " ")
+			if info, ok := c.findByName(name); ok && isSpecificName(name, info) {
+				return info, match[1], true
+			}
+		}
+	}
+	return LicenseInfo{}, "", false
+}
+
+// mentionedLicense is result of a license that is mentioned by its name instead of its text. Names like "GPL v2"
+// don't tell "-only" from "-or-later", so scope of GNU licenses is decided by the whole mention.
+func (c corpus) mentionedLicense(info LicenseInfo, mention string) DetectResult {
+	license, exception := c.resolveDeprecated(info, LicenseInfo{})
+	license, scope := c.withScope(license, mentionScope(mention))
+	return DetectResult{
+		Candidate:  Candidate{LicenseInfo: license, Confidence: 1},
+		Exception:  exception,
+		Expression: licenseExpression(license, exception),
+		Scope:      scope,
+	}
+}
+
+// shiftDeviations moves byte ranges of deviations of a section into ranges of the whole content
+func shiftDeviations(deviations []Deviation, offset int) []Deviation {
+	for index := range deviations {
+		deviations[index].Start += offset
+		deviations[index].End += offset
+	}
+	return deviations
+}
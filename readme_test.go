@@ -0,0 +1,85 @@
+package licensechecker
+
+import (
+	"testing"
+)
+
+func TestDetectReadme(t *testing.T) {
+	tests := []struct {
+		name      string
+		content   string
+		licenseID string
+		evidence  ReadmeEvidence
+		section   string
+		wantErr   error
+	}{
+		{
+			name:      "License text in section",
+			content:   "# Example\n\nAn example project.\n\n## Usage\n\n    go get example\n\n## License\n\n" + exampleMITContent + "\n## Contributing\n\nPull requests are welcome.\n",
+			licenseID: "MIT",
+			evidence:  ReadmeSection,
+			section:   "\n\n" + exampleMITContent + "\n",
+		},
+		{
+			name:      "Phrase in section",
+			content:   "Example\n=======\n\nUses Apache Commons.\n\nLicensing\n---------\n\nThis project is released under the Apache License, Version 2.0 (see LICENSE).\n",
+			licenseID: "Apache-2.0",
+			evidence:  ReadmePhrase,
+			section:   "\n\nThis project is released under the Apache License, Version 2.0 (see LICENSE).\n",
+		},
+		{
+			name:      "Badge",
+			content:   "# Example [![License: MIT](https://img.shields.io/badge/License-MIT-yellow.svg)](https://opensource.org/licenses/MIT)\n\nAn example project.\n",
+			licenseID: "MIT",
+			evidence:  ReadmeBadge,
+		},
+		{
+			name:      "Escaped badge",
+			content:   "<img src=\"https://img.shields.io/badge/license-Apache%202.0-blue.svg\">",
+			licenseID: "Apache-2.0",
+			evidence:  ReadmeBadge,
+		},
+		{
+			name:      "Phrase with version after name",
+			content:   "# Example\n\nThis library is released under LGPLv3.\n",
+			licenseID: "LGPL-3.0-only",
+			evidence:  ReadmePhrase,
+		},
+		{
+			name:    "Prose with ordinary words of license names",
+			content: "# Example\n\nEvery build is released under fair conditions for contributors. The archives are distributed under zlib compression, and the docs are available under the wiki.\n",
+			wantErr: ErrorNoMatch,
+		},
+		{
+			name:    "Prose of license section without license name",
+			content: "# Example\n\n## License\n\nThis project is published under fair terms, ask the maintainers.\n",
+			wantErr: ErrorNoMatch,
+		},
+		{
+			name:    "No license",
+			content: "# Example\n\nAn example project.\n",
+			wantErr: ErrorNoMatch,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DetectReadme([]byte(tt.content), "README.md")
+			if err != tt.wantErr {
+				t.Errorf("DetectReadme() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !got.LowTrust {
+				t.Errorf("DetectReadme() isn't labelled as low trust")
+			}
+			if err != nil {
+				return
+			}
+			if got.LicenseID != tt.licenseID || got.Evidence != tt.evidence {
+				t.Errorf("DetectReadme() = %v by %v, want %v by %v", got.LicenseID, got.Evidence, tt.licenseID, tt.evidence)
+			}
+			if section := tt.content[got.Section.Start:got.Section.End]; section != tt.section {
+				t.Errorf("DetectReadme() section = %q, want %q", section, tt.section)
+			}
+		})
+	}
+}
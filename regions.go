@@ -0,0 +1,244 @@
+package licensechecker
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/ledongthuc/licensechecker/format"
+	"github.com/ledongthuc/licensechecker/normalize"
+	"github.com/sergi/go-diff/diffmatchpatch"
+)
+
+const (
+	// numberOfRegionCandidates is maximum number of licenses that go from the n-gram index into region detection
+	numberOfRegionCandidates = 30
+	// minimumRegionGap is the lowest number of unmatched words allowed inside a license region
+	minimumRegionGap = 20
+	// maximumRegionOverlap is the highest fraction of a region that can overlap with an accepted region
+	maximumRegionOverlap = 0.1
+)
+
+// Region is a license found at byte range [Start, End) of content
+type Region struct {
+	LicenseInfo
+	Start      int
+	End        int
+	Confidence float64
+	// Exception is the license exception that follows the license text in the region, its LicenseID is empty if there isn't any
+	Exception LicenseInfo
+	// Expression is SPDX license expression of the region, e.g. "MIT" or "Apache-2.0 WITH LLVM-exception"
+	Expression string
+	// Scope tells whether a GNU license is "-only" or "-or-later" by the notice around the region
+	Scope Scope
+}
+
+// Span is a byte range [Start, End) of content
+type Span struct {
+	Start int
+	End   int
+}
+
+// RegionsResult contains licenses found in content ordered by their position, and spans of content that don't belong to any license
+type RegionsResult struct {
+	Regions   []Region
+	Unmatched []Span
+}
+
+// regionCandidate is the best region of a license in inputted words
+type regionCandidate struct {
+	entry      corpusEntry
+	first      int
+	last       int
+	matched    int
+	confidence float64
+	diffs      []diffmatchpatch.Diff
+}
+
+// better compares 2 regions, higher confidence goes first, then more matched words, then current licenses over deprecated ones
+func (r regionCandidate) better(other regionCandidate) bool {
+	if r.confidence != other.confidence {
+		return r.confidence > other.confidence
+	}
+	if r.matched != other.matched {
+		return r.matched > other.matched
+	}
+	return preferredOver(r.entry.info, other.entry.info)
+}
+
+// detectRegions finds all licenses in content. It repeatedly accepts the best region of all candidates, masks its words,
+// then finds again in the rest of content, so several licenses or several copies of one license can be found.
+func (c corpus) detectRegions(content []byte) (RegionsResult, error) {
+	text := format.Strip(content, format.Unknown)
+	stripped := []byte(text.Text)
+	tokens := normalize.Tokenize(text.Text)
+	words := make([]string, 0, len(tokens))
+	for _, token := range tokens {
+		words = append(words, token.Text)
+	}
+
+	shortlist := c.index.containing(words, numberOfRegionCandidates)
+	candidates := make([]regionCandidate, len(shortlist))
+	for index, item := range shortlist {
+		candidates[index] = regionOfAlignment(c.entries[item.index], alignWords(words, c.entries[item.index].words, item.first, item.last))
+	}
+
+	regions := []regionCandidate{}
+	for {
+		best := -1
+		for index, candidate := range candidates {
+			if candidate.confidence < MinimumConfidence || overlapsRegions(candidate, regions) {
+				continue
+			}
+			if best < 0 || candidate.better(candidates[best]) {
+				best = index
+			}
+		}
+		if best < 0 {
+			break
+		}
+
+		accepted := candidates[best]
+		regions = append(regions, accepted)
+		for index := accepted.first; index < accepted.last; index++ {
+			words[index] = ""
+		}
+		// Words of accepted region are masked, so regions that touch them are found again
+		for index, candidate := range candidates {
+			if candidate.first < accepted.last && accepted.first < candidate.last {
+				candidates[index] = findRegion(words, candidate.entry)
+			}
+		}
+	}
+
+	sort.Slice(regions, func(i, j int) bool {
+		return regions[i].first < regions[j].first
+	})
+	result := RegionsResult{Regions: make([]Region, 0, len(regions))}
+	previous := 0
+	for index := 0; index < len(regions); index++ {
+		region := regions[index]
+		last := region.last
+		r := Region{
+			LicenseInfo: region.entry.info,
+			Confidence:  region.confidence,
+		}
+		if index+1 < len(regions) && isFollowedByException(region, regions[index+1]) {
+			exception := regions[index+1]
+			r.Exception = exception.entry.info
+			last = exception.last
+			// A license with exception is as reliable as the weaker one of them
+			if exception.confidence < r.Confidence {
+				r.Confidence = exception.confidence
+			}
+			index++
+		}
+		r.LicenseInfo, r.Exception = c.resolveDeprecated(r.LicenseInfo, r.Exception)
+		// Notice of a region is the unmatched words between it and its neighbour regions
+		next := len(words)
+		if index+1 < len(regions) {
+			next = regions[index+1].first
+		}
+		notice := append(append([]string{}, words[previous:region.first]...), words[last:next]...)
+		r.LicenseInfo, r.Scope = c.withScope(r.LicenseInfo, noticeScope(notice))
+		previous = last
+		// Offsets of stripped text are mapped back, so regions point at the original content
+		r.Start, r.End = text.Span(tokens[region.first].Start, extendPunctuation(stripped, tokens[last-1].End))
+		r.Expression = licenseExpression(r.LicenseInfo, r.Exception)
+		result.Regions = append(result.Regions, r)
+	}
+	result.Unmatched = unmatchedSpans(content, result.Regions)
+
+	if len(result.Regions) == 0 {
+		return result, ErrorNoMatch
+	}
+	return result, nil
+}
+
+// findRegion aligns license words with the window of inputted words where the license is, and keeps the densest group
+// of matched words as license region
+func findRegion(words []string, entry corpusEntry) regionCandidate {
+	first, last := licenseWindow(words, entry.words)
+	return regionOfAlignment(entry, alignWords(words, entry.words, first, last))
+}
+
+// regionOfAlignment keeps the densest group of matched words of an alignment as license region
+func regionOfAlignment(entry corpusEntry, alignment wordAlignment) regionCandidate {
+	maxGap := minimumRegionGap
+	if gap := len(entry.words) / 10; gap > maxGap {
+		maxGap = gap
+	}
+	a := alignment.densestCluster(maxGap)
+	return regionCandidate{
+		entry:      entry,
+		first:      a.first,
+		last:       a.last,
+		matched:    a.matched,
+		confidence: a.confidence(len(entry.words)),
+		diffs:      a.diffs,
+	}
+}
+
+// overlapsRegions checks whether a candidate overlaps too much with accepted regions
+func overlapsRegions(candidate regionCandidate, regions []regionCandidate) bool {
+	length := candidate.last - candidate.first
+	for _, region := range regions {
+		first, last := candidate.first, candidate.last
+		if region.first > first {
+			first = region.first
+		}
+		if region.last < last {
+			last = region.last
+		}
+		if last > first && float64(last-first) > maximumRegionOverlap*float64(length) {
+			return true
+		}
+	}
+	return false
+}
+
+// extendPunctuation moves end of a region over punctuation right after it, e.g. the full stop of last sentence
+func extendPunctuation(content []byte, end int) int {
+	for end < len(content) {
+		r, size := utf8.DecodeRune(content[end:])
+		if unicode.IsSpace(r) || isWordRune(r) {
+			break
+		}
+		end += size
+	}
+	return end
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsNumber(r)
+}
+
+// unmatchedSpans returns the parts of content outside of regions, parts without any word are skipped
+func unmatchedSpans(content []byte, regions []Region) []Span {
+	spans := []Span{}
+	appendSpan := func(start, end int) {
+		text := string(content[start:end])
+		trimmedLeft := strings.TrimLeftFunc(text, unicode.IsSpace)
+		trimmed := strings.TrimRightFunc(trimmedLeft, unicode.IsSpace)
+		if strings.IndexFunc(trimmed, isWordRune) < 0 {
+			return
+		}
+		start += len(text) - len(trimmedLeft)
+		spans = append(spans, Span{Start: start, End: start + len(trimmed)})
+	}
+
+	position := 0
+	for _, region := range regions {
+		if region.Start > position {
+			appendSpan(position, region.Start)
+		}
+		if region.End > position {
+			position = region.End
+		}
+	}
+	if position < len(content) {
+		appendSpan(position, len(content))
+	}
+	return spans
+}
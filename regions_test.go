@@ -0,0 +1,106 @@
+package licensechecker
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ledongthuc/licensechecker/internal/data"
+)
+
+func TestDetectRegions(t *testing.T) {
+	contentApache, _ := data.Asset("Apache-2.0.txt")
+	header := "THIRD PARTY NOTICES\n\nThis product bundles the following components.\n\n"
+	separator := "\n\n========================================\nComponent: tiny-lib\n\n"
+
+	tests := []struct {
+		name       string
+		content    string
+		licenseIDs []string
+		unmatched  []string
+		wantErr    error
+	}{
+		{
+			name:       "One license",
+			content:    exampleMITContent,
+			licenseIDs: []string{"MIT"},
+			unmatched:  []string{},
+		},
+		{
+			name:       "Several licenses",
+			content:    header + exampleMITContent + separator + string(contentApache),
+			licenseIDs: []string{"MIT", "Apache-2.0"},
+			unmatched:  []string{strings.TrimSpace(header), strings.TrimSpace(separator)},
+		},
+		{
+			name:       "Several copies of one license",
+			content:    exampleMITContent + separator + exampleMITContent,
+			licenseIDs: []string{"MIT", "MIT"},
+			unmatched:  []string{strings.TrimSpace(separator)},
+		},
+		{
+			name:      "Not a license",
+			content:   "Lorem ipsum dolor sit amet",
+			unmatched: []string{"Lorem ipsum dolor sit amet"},
+			wantErr:   ErrorNoMatch,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DetectRegions([]byte(tt.content))
+			if err != tt.wantErr {
+				t.Errorf("DetectRegions() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			licenseIDs := []string{}
+			for _, region := range got.Regions {
+				licenseIDs = append(licenseIDs, region.LicenseID)
+				if region.Confidence < MinimumConfidence || region.Confidence > 1 {
+					t.Errorf("DetectRegions() region %s has confidence %v", region.LicenseID, region.Confidence)
+				}
+			}
+			if len(tt.licenseIDs) > 0 && !reflect.DeepEqual(licenseIDs, tt.licenseIDs) {
+				t.Errorf("DetectRegions() licenses = %v, want %v", licenseIDs, tt.licenseIDs)
+			}
+			unmatched := []string{}
+			for _, span := range got.Unmatched {
+				unmatched = append(unmatched, tt.content[span.Start:span.End])
+			}
+			if !reflect.DeepEqual(unmatched, tt.unmatched) {
+				t.Errorf("DetectRegions() unmatched = %q, want %q", unmatched, tt.unmatched)
+			}
+		})
+	}
+}
+
+func TestDetectRegions_markdown(t *testing.T) {
+	header := "# Example\n\n[![Build](https://ci.example.com/badge.svg)](https://ci.example.com)\n\n## License\n\n"
+	body := strings.Replace(exampleMITContent, "Permission is hereby granted", "Permission is **hereby** granted", 1)
+	content := header + body
+
+	got, err := DetectRegions([]byte(content))
+	if err != nil {
+		t.Errorf("DetectRegions() error = %v", err)
+		return
+	}
+	if len(got.Regions) != 1 || got.Regions[0].LicenseID != "MIT" {
+		t.Errorf("DetectRegions() regions = %+v, want MIT", got.Regions)
+		return
+	}
+	region := content[got.Regions[0].Start:got.Regions[0].End]
+	if !strings.HasPrefix(region, "MIT License") || !strings.HasSuffix(region, "SOFTWARE.") {
+		t.Errorf("DetectRegions() region = %q, want the MIT text of content", region)
+	}
+}
+
+func Test_unmatchedSpans(t *testing.T) {
+	content := []byte("  head \n[license one] middle [license two]\n tail \n")
+	regions := []Region{
+		{Start: 8, End: 21},
+		{Start: 29, End: 42},
+	}
+	want := []Span{{Start: 2, End: 6}, {Start: 22, End: 28}, {Start: 44, End: 48}}
+	if got := unmatchedSpans(content, regions); !reflect.DeepEqual(got, want) {
+		t.Errorf("unmatchedSpans() = %v, want %v", got, want)
+	}
+}
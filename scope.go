@@ -0,0 +1,138 @@
+package licensechecker
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/ledongthuc/licensechecker/normalize"
+)
+
+// Scope tells which versions of a GNU license are granted. Full texts of GPL, LGPL, AGPL and GFDL are the same for
+// "-only" and "-or-later", the notice that applies the license to a work makes the difference.
+type Scope int
+
+const (
+	// ScopeNone is for licenses that don't have "-only" and "-or-later" variants
+	ScopeNone Scope = iota
+	// ScopeOnly grants the stated version of license only
+	ScopeOnly
+	// ScopeOrLater grants the stated version of license or any later version
+	ScopeOrLater
+	// ScopeAmbiguous is for a GNU license without any notice about its versions. LicenseInfo is the "-only" variant,
+	// which is what SPDX maps the deprecated ID without scope into, e.g. "GPL-2.0-only" for "GPL-2.0".
+	ScopeAmbiguous
+)
+
+func (s Scope) String() string {
+	switch s {
+	case ScopeOnly:
+		return "only"
+	case ScopeOrLater:
+		return "or-later"
+	case ScopeAmbiguous:
+		return "ambiguous"
+	}
+	return "none"
+}
+
+var (
+	// orLaterPattern matches notice words that grant later versions, e.g. "or (at your option) any later version"
+	orLaterPattern = regexp.MustCompile(`\b(?:any later version|or later)\b`)
+	// onlyPattern matches notice words that limit license to one version, e.g. "version 2 only" or "GPL-2.0-only"
+	onlyPattern = regexp.MustCompile(`\d only\b|\bonly version\b`)
+	// versionNoticePattern matches notices that state one version without "or later", e.g. "GNU General Public License
+	// version 2 as published by the Free Software Foundation"
+	versionNoticePattern = regexp.MustCompile(`\blicense (?:version |v)\d+(?: \d+)? as published by the free software foundation\b`)
+	// plusVersionPattern matches versions with "+", e.g. "GPLv2+". Words don't keep "+", so it's matched on text.
+	plusVersionPattern = regexp.MustCompile(`\d\+`)
+	// gnuNoticePattern matches the GNU license that a notice names, e.g. "GNU Lesser General Public License"
+	gnuNoticePattern = regexp.MustCompile(`\bgnu (general|lesser general|library general|affero general|free documentation) (?:public )?license\b`)
+	// noticeVersionPattern matches version of license in notice words, e.g. "version 2 1" of "version 2.1"
+	noticeVersionPattern = regexp.MustCompile(`\b(?:version |v)(\d+)(?: (\d+))?\b`)
+)
+
+// gnuFamilies are SPDX ID prefixes of GNU licenses that are named by notices
+var gnuFamilies = map[string]string{
+	"general":            "GPL",
+	"lesser general":     "LGPL",
+	"library general":    "LGPL",
+	"affero general":     "AGPL",
+	"free documentation": "GFDL",
+}
+
+// noticeScope decides scope of a GNU license by words of its notice, it's ScopeAmbiguous if the notice doesn't tell
+func noticeScope(words []string) Scope {
+	text := strings.Join(words, " ")
+	switch {
+	case orLaterPattern.MatchString(text):
+		return ScopeOrLater
+	case onlyPattern.MatchString(text), versionNoticePattern.MatchString(text):
+		return ScopeOnly
+	}
+	return ScopeAmbiguous
+}
+
+// mentionScope decides scope of a GNU license by the text that mentions it, e.g. "GPLv2+" or "GPL v3 or later"
+func mentionScope(mention string) Scope {
+	if plusVersionPattern.MatchString(mention) {
+		return ScopeOrLater
+	}
+	return noticeScope(normalize.Words(mention))
+}
+
+// scopeOfID is the scope that an SPDX license ID states
+func scopeOfID(licenseID string) Scope {
+	switch {
+	case strings.HasSuffix(licenseID, "-only"):
+		return ScopeOnly
+	case strings.HasSuffix(licenseID, "-or-later"):
+		return ScopeOrLater
+	}
+	return ScopeNone
+}
+
+// withScope replaces a license that has "-only" and "-or-later" variants by its variant of scope. The "-only" variant
+// stands for ScopeAmbiguous, so results never have the deprecated ID without scope. Other licenses are returned as is
+// with ScopeNone.
+func (c corpus) withScope(info LicenseInfo, scope Scope) (LicenseInfo, Scope) {
+	base := strings.TrimSuffix(strings.TrimSuffix(info.LicenseID, "-only"), "-or-later")
+	if base == info.LicenseID {
+		return info, ScopeNone
+	}
+	suffix := "-only"
+	if scope == ScopeOrLater {
+		suffix = "-or-later"
+	}
+	if variant, ok := c.find(base + suffix); ok {
+		return variant, scope
+	}
+	return info, ScopeNone
+}
+
+// detectNotice finds a GNU license by its notice, e.g. "under the terms of the GNU General Public License version 2
+// as published by the Free Software Foundation". Notices aren't standard, so it's used when standard headers don't match.
+func (c corpus) detectNotice(text string) (DetectResult, bool) {
+	words := normalize.Words(text)
+	joined := strings.Join(words, " ")
+	family := gnuNoticePattern.FindStringSubmatchIndex(joined)
+	if family == nil {
+		return DetectResult{}, false
+	}
+	version := noticeVersionPattern.FindStringSubmatch(joined[family[1]:])
+	if version == nil {
+		return DetectResult{}, false
+	}
+	minor := version[2]
+	if minor == "" {
+		minor = "0"
+	}
+	info, ok := c.find(gnuFamilies[joined[family[2]:family[3]]] + "-" + version[1] + "." + minor + "-only")
+	if !ok {
+		return DetectResult{}, false
+	}
+
+	result := DetectResult{Candidate: Candidate{Confidence: 1}}
+	result.LicenseInfo, result.Scope = c.withScope(info, noticeScope(words))
+	result.Expression = licenseExpression(result.LicenseInfo, LicenseInfo{})
+	return result, true
+}
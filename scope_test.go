@@ -0,0 +1,213 @@
+package licensechecker
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ledongthuc/licensechecker/internal/data"
+	"github.com/ledongthuc/licensechecker/normalize"
+)
+
+const (
+	exampleOrLaterNotice = `This program is free software; you can redistribute it and/or modify it under the terms of the
+GNU General Public License as published by the Free Software Foundation; either version 2 of the License, or
+(at your option) any later version.`
+	exampleOnlyNotice = `This program is free software; you can redistribute it and/or modify it under the terms of the
+GNU General Public License version 2 as published by the Free Software Foundation.`
+)
+
+func Test_noticeScope(t *testing.T) {
+	tests := []struct {
+		name   string
+		notice string
+		want   Scope
+	}{
+		{name: "Or later", notice: exampleOrLaterNotice, want: ScopeOrLater},
+		{name: "Version as published", notice: exampleOnlyNotice, want: ScopeOnly},
+		{name: "Only", notice: "Licensed under GPL-3.0-only.", want: ScopeOnly},
+		{name: "No notice", notice: "Copyright (c) 2019 Thuc Le", want: ScopeAmbiguous},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := noticeScope(normalize.Words(tt.notice)); got != tt.want {
+				t.Errorf("noticeScope() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDetect_scope(t *testing.T) {
+	contentGPL, _ := data.Asset("GPL-2.0-only.txt")
+	tests := []struct {
+		name       string
+		content    string
+		licenseID  string
+		scope      Scope
+		expression string
+	}{
+		{
+			name:       "Or later notice",
+			content:    exampleOrLaterNotice + "\n\n" + string(contentGPL),
+			licenseID:  "GPL-2.0-or-later",
+			scope:      ScopeOrLater,
+			expression: "GPL-2.0-or-later",
+		},
+		{
+			name:       "Only notice",
+			content:    exampleOnlyNotice + "\n\n" + string(contentGPL),
+			licenseID:  "GPL-2.0-only",
+			scope:      ScopeOnly,
+			expression: "GPL-2.0-only",
+		},
+		{
+			name:       "License text alone",
+			content:    string(contentGPL),
+			licenseID:  "GPL-2.0-only",
+			scope:      ScopeAmbiguous,
+			expression: "GPL-2.0-only",
+		},
+		{
+			name:       "Not a GNU license",
+			content:    exampleMITContent,
+			licenseID:  "MIT",
+			scope:      ScopeNone,
+			expression: "MIT",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DetectWithOptions([]byte(tt.content), DetectOptions{})
+			if err != nil {
+				t.Errorf("DetectWithOptions() error = %v", err)
+				return
+			}
+			if got.LicenseID != tt.licenseID || got.Scope != tt.scope || got.Expression != tt.expression {
+				t.Errorf("DetectWithOptions() = %v, %v, %q, want %v, %v, %q", got.LicenseID, got.Scope, got.Expression, tt.licenseID, tt.scope, tt.expression)
+			}
+		})
+	}
+}
+
+func TestDetectRegions_scope(t *testing.T) {
+	contentGPL, _ := data.Asset("GPL-2.0-only.txt")
+	content := exampleMITContent + "\n\n----------\n\n" + exampleOrLaterNotice + "\n\n" + string(contentGPL)
+
+	got, err := DetectRegions([]byte(content))
+	if err != nil {
+		t.Errorf("DetectRegions() error = %v", err)
+		return
+	}
+	if len(got.Regions) != 2 || got.Regions[0].Scope != ScopeNone || got.Regions[1].Expression != "GPL-2.0-or-later" {
+		t.Errorf("DetectRegions() = %+v, want MIT and GPL-2.0-or-later", got.Regions)
+	}
+}
+
+func TestDetectSource_scope(t *testing.T) {
+	tests := []struct {
+		name       string
+		content    string
+		licenseID  string
+		expression string
+		scope      Scope
+	}{
+		{
+			name:       "Standard header",
+			content:    "// " + strings.Replace(exampleOrLaterNotice, "\n", "\n// ", -1) + "\n\npackage main\n",
+			licenseID:  "GPL-2.0-or-later",
+			expression: "GPL-2.0-or-later",
+			scope:      ScopeOrLater,
+		},
+		{
+			name:       "Version only notice",
+			content:    "// " + strings.Replace(exampleOnlyNotice, "\n", "\n// ", -1) + "\n\npackage main\n",
+			licenseID:  "GPL-2.0-only",
+			expression: "GPL-2.0-only",
+			scope:      ScopeOnly,
+		},
+		{
+			name:       "LGPL notice",
+			content:    "// Licensed under the GNU Lesser General Public License, version 2.1 or later.\n\npackage main\n",
+			licenseID:  "LGPL-2.1-or-later",
+			expression: "LGPL-2.1-or-later",
+			scope:      ScopeOrLater,
+		},
+		{
+			name:       "Notice without scope",
+			content:    "// This file is part of Example, licensed under the GNU Affero General Public License v3.\n\npackage main\n",
+			licenseID:  "AGPL-3.0-only",
+			expression: "AGPL-3.0-only",
+			scope:      ScopeAmbiguous,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DetectSource([]byte(tt.content), "main.go")
+			if err != nil {
+				t.Errorf("DetectSource() error = %v", err)
+				return
+			}
+			if got.LicenseID != tt.licenseID || got.Expression != tt.expression || got.Scope != tt.scope {
+				t.Errorf("DetectSource() = %v, %q, %v, want %v, %q, %v", got.LicenseID, got.Expression, got.Scope, tt.licenseID, tt.expression, tt.scope)
+			}
+		})
+	}
+}
+
+func TestDetectReadme_scope(t *testing.T) {
+	tests := []struct {
+		name       string
+		content    string
+		expression string
+		scope      Scope
+	}{
+		{
+			name:       "Or later",
+			content:    "This project is released under the GNU General Public License v3.0 or later.",
+			expression: "GPL-3.0-or-later",
+			scope:      ScopeOrLater,
+		},
+		{
+			name:       "Plus",
+			content:    "This project is licensed under GPL-2.0+.",
+			expression: "GPL-2.0-or-later",
+			scope:      ScopeOrLater,
+		},
+		{
+			name:       "Version without scope",
+			content:    "This project is licensed under GPL-3.0.",
+			expression: "GPL-3.0-only",
+			scope:      ScopeAmbiguous,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DetectReadme([]byte(tt.content), "README.md")
+			if err != nil {
+				t.Errorf("DetectReadme() error = %v", err)
+				return
+			}
+			if got.Expression != tt.expression || got.Scope != tt.scope {
+				t.Errorf("DetectReadme() = %q, %v, want %q, %v", got.Expression, got.Scope, tt.expression, tt.scope)
+			}
+		})
+	}
+}
+
+func TestScope_String(t *testing.T) {
+	tests := []struct {
+		scope Scope
+		want  string
+	}{
+		{scope: ScopeNone, want: "none"},
+		{scope: ScopeOnly, want: "only"},
+		{scope: ScopeOrLater, want: "or-later"},
+		{scope: ScopeAmbiguous, want: "ambiguous"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.want, func(t *testing.T) {
+			if got := tt.scope.String(); got != tt.want {
+				t.Errorf("Scope.String() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,95 @@
+// Command fingerprints generates the table of fingerprints of normalized license texts, so verbatim copies of
+// licenses are detected without fuzzy matching. It's run by "make update":
+//
+//	go run ./scripts/fingerprints license-list-data/text internal/fingerprints/table.go
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/ledongthuc/licensechecker/normalize"
+	"github.com/pkg/errors"
+)
+
+// deprecatedPrefix is file name prefix of deprecated licenses in SPDX license list data
+const deprecatedPrefix = "deprecated_"
+
+func main() {
+	if len(os.Args) != 3 {
+		fmt.Println("Usage: fingerprints <directory of license texts> <output file>")
+		os.Exit(1)
+	}
+	if err := generate(os.Args[1], os.Args[2]); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
+
+// generate hashes all license texts of directory and writes them as Go source. Licenses that have the same text
+// share a fingerprint, then the current one with the lowest ID is kept.
+func generate(directory, output string) error {
+	paths, err := filepath.Glob(filepath.Join(directory, "*.txt"))
+	if err != nil {
+		return errors.Wrap(err, "Error when list license texts of '"+directory+"'")
+	}
+	// Current licenses go first, then IDs are in order, so the first license of a fingerprint is kept
+	sort.Slice(paths, func(i, j int) bool {
+		a, b := filepath.Base(paths[i]), filepath.Base(paths[j])
+		if strings.HasPrefix(a, deprecatedPrefix) != strings.HasPrefix(b, deprecatedPrefix) {
+			return !strings.HasPrefix(a, deprecatedPrefix)
+		}
+		return a < b
+	})
+
+	fingerprints := map[string]string{}
+	for _, path := range paths {
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return errors.Wrap(err, "Error when read license text '"+path+"'")
+		}
+		words := normalize.Words(string(content))
+		if len(words) == 0 {
+			continue
+		}
+		key := normalize.Fingerprint(words)
+		if _, ok := fingerprints[key]; !ok {
+			fingerprints[key] = strings.TrimSuffix(strings.TrimPrefix(filepath.Base(path), deprecatedPrefix), ".txt")
+		}
+	}
+
+	keys := make([]string, 0, len(fingerprints))
+	for key := range fingerprints {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var source bytes.Buffer
+	source.WriteString("// Code generated by scripts/fingerprints. DO NOT EDIT.\n\n")
+	source.WriteString("package fingerprints\n\n")
+	source.WriteString("func init() {\n")
+	source.WriteString("\tFingerprints = map[string]string{\n")
+	for _, key := range keys {
+		fmt.Fprintf(&source, "\t\t%q: %q,\n", key, fingerprints[key])
+	}
+	source.WriteString("\t}\n")
+	source.WriteString("}\n")
+
+	formatted, err := format.Source(source.Bytes())
+	if err != nil {
+		return errors.Wrap(err, "Error when format fingerprints")
+	}
+	if err := os.MkdirAll(filepath.Dir(output), 0755); err != nil {
+		return errors.Wrap(err, "Error when create directory of '"+output+"'")
+	}
+	if err := ioutil.WriteFile(output, formatted, 0644); err != nil {
+		return errors.Wrap(err, "Error when write fingerprints into '"+output+"'")
+	}
+	return nil
+}
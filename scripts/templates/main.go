@@ -0,0 +1,91 @@
+// Command templates generates Go source of SPDX license templates, so the template package has them without reading
+// files. It's run by "make update":
+//
+//	go run ./scripts/templates license-list-data/template template/spdx_templates.go
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	// deprecatedPrefix is file name prefix of deprecated licenses in SPDX license list data
+	deprecatedPrefix = "deprecated_"
+	// templateSuffix is file extension of SPDX license templates, e.g. "MIT.template.txt"
+	templateSuffix = ".template.txt"
+)
+
+func main() {
+	if len(os.Args) != 3 {
+		fmt.Println("Usage: templates <directory of license templates> <output file>")
+		os.Exit(1)
+	}
+	if err := generate(os.Args[1], os.Args[2]); err != nil {
+		fmt.Println(err)
+		os.Exit(1)
+	}
+}
+
+// generate reads all license templates of directory and writes them as Go source. A deprecated license is kept by its
+// ID without prefix, unless a current license has the same ID.
+func generate(directory, output string) error {
+	paths, err := filepath.Glob(filepath.Join(directory, "*"+templateSuffix))
+	if err != nil {
+		return errors.Wrap(err, "Error when list license templates of '"+directory+"'")
+	}
+
+	templates := map[string]string{}
+	deprecated := map[string]string{}
+	for _, path := range paths {
+		content, err := ioutil.ReadFile(path)
+		if err != nil {
+			return errors.Wrap(err, "Error when read license template '"+path+"'")
+		}
+		name := strings.TrimSuffix(filepath.Base(path), templateSuffix)
+		if strings.HasPrefix(name, deprecatedPrefix) {
+			deprecated[strings.TrimPrefix(name, deprecatedPrefix)] = string(content)
+			continue
+		}
+		templates[name] = string(content)
+	}
+	for licenseID, content := range deprecated {
+		if _, ok := templates[licenseID]; !ok {
+			templates[licenseID] = content
+		}
+	}
+
+	licenseIDs := make([]string, 0, len(templates))
+	for licenseID := range templates {
+		licenseIDs = append(licenseIDs, licenseID)
+	}
+	sort.Strings(licenseIDs)
+
+	var source bytes.Buffer
+	source.WriteString("// Code generated by scripts/templates. DO NOT EDIT.\n\n")
+	source.WriteString("package template\n\n")
+	source.WriteString("func init() {\n")
+	source.WriteString("\tspdxTemplates = map[string]string{\n")
+	for _, licenseID := range licenseIDs {
+		fmt.Fprintf(&source, "\t\t%q: %q,\n", licenseID, templates[licenseID])
+	}
+	source.WriteString("\t}\n")
+	source.WriteString("}\n")
+
+	formatted, err := format.Source(source.Bytes())
+	if err != nil {
+		return errors.Wrap(err, "Error when format templates")
+	}
+	if err := ioutil.WriteFile(output, formatted, 0644); err != nil {
+		return errors.Wrap(err, "Error when write templates into '"+output+"'")
+	}
+	return nil
+}
@@ -0,0 +1,205 @@
+package licensechecker
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"regexp"
+	"strings"
+
+	"github.com/ledongthuc/licensechecker/template"
+	"github.com/pkg/errors"
+)
+
+var (
+	// spdxIdentifierPattern matches SPDX short-form identifier tag, the expression ends with the line
+	spdxIdentifierPattern = regexp.MustCompile(`(?m)SPDX-License-Identifier:[ \t]*(.*?)[ \t]*$`)
+	// simpleExpressionPattern matches an expression of one license, with or without exception
+	simpleExpressionPattern = regexp.MustCompile(`^\(?([\w.+-]+)(?:\s+WITH\s+([\w.+-]+))?\)?$`)
+)
+
+// commentStyle defines comment syntax of a programming language
+type commentStyle struct {
+	linePrefixes []string
+	blockStart   string
+	blockEnd     string
+}
+
+var (
+	cStyle      = commentStyle{linePrefixes: []string{"//"}, blockStart: "/*", blockEnd: "*/"}
+	hashStyle   = commentStyle{linePrefixes: []string{"#"}}
+	dashStyle   = commentStyle{linePrefixes: []string{"--"}}
+	markupStyle = commentStyle{blockStart: "<!--", blockEnd: "-->"}
+	// defaultStyle is used for unknown file types
+	defaultStyle = commentStyle{linePrefixes: []string{"//", "#"}, blockStart: "/*", blockEnd: "*/"}
+)
+
+// commentStyles are comment syntaxes by file extensions, or by file names for files that don't have extension
+var commentStyles = map[string]commentStyle{
+	".go": cStyle, ".c": cStyle, ".h": cStyle, ".cc": cStyle, ".cpp": cStyle, ".hpp": cStyle, ".cs": cStyle,
+	".java": cStyle, ".kt": cStyle, ".scala": cStyle, ".groovy": cStyle, ".swift": cStyle, ".m": cStyle,
+	".js": cStyle, ".jsx": cStyle, ".ts": cStyle, ".tsx": cStyle, ".rs": cStyle, ".php": cStyle, ".dart": cStyle,
+	".css": cStyle, ".scss": cStyle, ".less": cStyle, ".proto": cStyle,
+
+	".py": hashStyle, ".rb": hashStyle, ".sh": hashStyle, ".bash": hashStyle, ".zsh": hashStyle, ".pl": hashStyle,
+	".r": hashStyle, ".yaml": hashStyle, ".yml": hashStyle, ".toml": hashStyle, ".cmake": hashStyle,
+	"makefile": hashStyle, "dockerfile": hashStyle,
+
+	".sql": dashStyle, ".lua": dashStyle, ".hs": dashStyle,
+
+	".html": markupStyle, ".htm": markupStyle, ".xml": markupStyle, ".xsd": markupStyle, ".svg": markupStyle,
+	".vue": markupStyle,
+}
+
+// SourceFileResult contains license of a source file, which is found by its header
+type SourceFileResult struct {
+	DetectResult
+	// SPDXIdentifier is the expression of "SPDX-License-Identifier:" tag, it's empty if the file doesn't have any tag
+	SPDXIdentifier string
+	// Header is text of the leading comment block, without comment markers
+	Header     string
+	Copyrights []Copyright
+}
+
+// DetectSourceFile reads a source file and detects the license of its header. See DetectSource.
+func DetectSourceFile(path string) (SourceFileResult, error) {
+	content, err := ioutil.ReadFile(path)
+	if err != nil {
+		return SourceFileResult{}, errors.Wrap(err, "Error when read source file '"+path+"'")
+	}
+	return DetectSource(content, filepath.Base(path))
+}
+
+// DetectSource detects the license of source code by its header, comment syntax is chosen by extension of fileName.
+// An "SPDX-License-Identifier:" tag is taken directly. Otherwise, the header is matched with standard license headers,
+// then with notices of GNU licenses, then with full license texts.
+func DetectSource(content []byte, fileName string) (SourceFileResult, error) {
+	c, err := loadCorpus()
+	if err != nil {
+		return SourceFileResult{}, err
+	}
+
+	header := extractHeader(string(content), findCommentStyle(fileName))
+	result := SourceFileResult{
+		Header:     header,
+		Copyrights: ExtractCopyrights([]byte(header)),
+	}
+
+	if match := spdxIdentifierPattern.FindStringSubmatch(header); match != nil && match[1] != "" {
+		result.SPDXIdentifier = match[1]
+		result.DetectResult = c.detectIdentifier(match[1])
+		return result, nil
+	}
+
+	if licenseID, variables, ok := template.MatchHeader(header); ok {
+		if info, ok := c.find(licenseID); ok {
+			result.LicenseInfo = info
+			result.Confidence = 1
+			result.Coverage = 1
+			result.Variables = variables
+			result.Expression = licenseID
+			result.Scope = scopeOfID(licenseID)
+			return result, nil
+		}
+	}
+
+	if detected, ok := c.detectNotice(header); ok {
+		result.DetectResult = detected
+		return result, nil
+	}
+
+	result.DetectResult, err = c.detect([]byte(header), DetectOptions{})
+	return result, err
+}
+
+// detectIdentifier converts SPDX identifier tag into result. License and exception are filled when the expression
+// is one license with or without exception, and they're known licenses.
+func (c corpus) detectIdentifier(identifier string) DetectResult {
+	result := DetectResult{Expression: resolveDeprecatedIDs(identifier)}
+	match := simpleExpressionPattern.FindStringSubmatch(identifier)
+	if match == nil {
+		return result
+	}
+	if info, ok := c.find(match[1]); ok {
+		result.LicenseInfo = info
+		result.Confidence = 1
+		result.Coverage = 1
+	}
+	if info, ok := c.find(match[2]); ok {
+		result.Exception = info
+	}
+	// Deprecated IDs are kept in SPDXIdentifier, expression of result is current
+	result.LicenseInfo, result.Exception = c.resolveDeprecated(result.LicenseInfo, result.Exception)
+	if result.LicenseID != "" {
+		result.Expression = licenseExpression(result.LicenseInfo, result.Exception)
+		result.Scope = scopeOfID(result.LicenseID)
+	}
+	return result
+}
+
+func findCommentStyle(fileName string) commentStyle {
+	name := strings.ToLower(fileName)
+	if style, ok := commentStyles[filepath.Ext(name)]; ok {
+		return style
+	}
+	if style, ok := commentStyles[name]; ok {
+		return style
+	}
+	return defaultStyle
+}
+
+// extractHeader collects the comment blocks at beginning of source code, until the first line of code.
+// Shebang and XML declaration lines are skipped, comment markers and " * " prefixes of block comments are stripped.
+func extractHeader(content string, style commentStyle) string {
+	lines := strings.Split(strings.Replace(content, "\r\n", "\n", -1), "\n")
+	header := []string{}
+	inBlock := false
+	for index, line := range lines {
+		trimmed := strings.TrimSpace(line)
+		if inBlock {
+			if end := strings.Index(trimmed, style.blockEnd); end >= 0 {
+				trimmed = trimmed[:end]
+				inBlock = false
+			}
+			header = append(header, stripBlockPrefix(trimmed))
+			continue
+		}
+
+		switch {
+		case trimmed == "":
+			header = append(header, "")
+		case index == 0 && strings.HasPrefix(trimmed, "#!"),
+			strings.HasPrefix(trimmed, "<?xml"),
+			strings.HasPrefix(strings.ToLower(trimmed), "<!doctype"):
+			continue
+		case style.blockStart != "" && strings.HasPrefix(trimmed, style.blockStart):
+			trimmed = trimmed[len(style.blockStart):]
+			if end := strings.Index(trimmed, style.blockEnd); end >= 0 {
+				trimmed = trimmed[:end]
+			} else {
+				inBlock = true
+			}
+			header = append(header, stripBlockPrefix(trimmed))
+		default:
+			prefix := linePrefix(trimmed, style.linePrefixes)
+			if prefix == "" {
+				return strings.TrimSpace(strings.Join(header, "\n"))
+			}
+			header = append(header, strings.TrimSpace(strings.TrimLeft(trimmed[len(prefix):], prefix[:1])))
+		}
+	}
+	return strings.TrimSpace(strings.Join(header, "\n"))
+}
+
+// stripBlockPrefix removes the " * " prefix of lines inside a block comment, e.g. "/** ... */" of C and Java
+func stripBlockPrefix(line string) string {
+	return strings.TrimSpace(strings.TrimLeft(strings.TrimSpace(line), "*"))
+}
+
+func linePrefix(line string, prefixes []string) string {
+	for _, prefix := range prefixes {
+		if strings.HasPrefix(line, prefix) {
+			return prefix
+		}
+	}
+	return ""
+}
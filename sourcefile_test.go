@@ -0,0 +1,173 @@
+package licensechecker
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+const exampleApacheGoSource = `// Copyright 2019 The Example Authors.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//     http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package example
+`
+
+func TestDetectSource(t *testing.T) {
+	tests := []struct {
+		name           string
+		fileName       string
+		content        string
+		expression     string
+		spdxIdentifier string
+		holders        []string
+		wantErr        bool
+	}{
+		{
+			name:       "Standard header in line comments",
+			fileName:   "example.go",
+			content:    exampleApacheGoSource,
+			expression: "Apache-2.0",
+			holders:    []string{"The Example Authors"},
+		},
+		{
+			name:           "SPDX identifier after shebang",
+			fileName:       "run.py",
+			content:        "#!/usr/bin/env python\n# SPDX-License-Identifier: GPL-2.0-only WITH Classpath-exception-2.0\n\nimport os\n",
+			expression:     "GPL-2.0-only WITH Classpath-exception-2.0",
+			spdxIdentifier: "GPL-2.0-only WITH Classpath-exception-2.0",
+			holders:        []string{},
+		},
+		{
+			name:           "SPDX identifier in block comment",
+			fileName:       "main.c",
+			content:        "/* SPDX-License-Identifier: MIT OR Apache-2.0 */\n#include <stdio.h>\n",
+			expression:     "MIT OR Apache-2.0",
+			spdxIdentifier: "MIT OR Apache-2.0",
+			holders:        []string{},
+		},
+		{
+			name:       "Full license text in block comment",
+			fileName:   "Main.java",
+			content:    "/*\n * " + strings.Replace(exampleMITContent, "\n", "\n * ", -1) + "\n */\npackage example;\n",
+			expression: "MIT",
+			holders:    []string{"Thuc Le"},
+		},
+		{
+			name:     "No header",
+			fileName: "index.js",
+			content:  "console.log('hello')\n",
+			holders:  []string{},
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := DetectSource([]byte(tt.content), tt.fileName)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("DetectSource() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if got.Expression != tt.expression {
+				t.Errorf("DetectSource() expression = %v, want %v", got.Expression, tt.expression)
+			}
+			if got.SPDXIdentifier != tt.spdxIdentifier {
+				t.Errorf("DetectSource() SPDXIdentifier = %v, want %v", got.SPDXIdentifier, tt.spdxIdentifier)
+			}
+			holders := []string{}
+			for _, copyright := range got.Copyrights {
+				holders = append(holders, copyright.Holders...)
+			}
+			if !reflect.DeepEqual(holders, tt.holders) {
+				t.Errorf("DetectSource() holders = %v, want %v", holders, tt.holders)
+			}
+		})
+	}
+}
+
+func TestDetectSourceFile(t *testing.T) {
+	dir, err := ioutil.TempDir("", "licensechecker")
+	if err != nil {
+		t.Errorf("TempDir() error = %v", err)
+		return
+	}
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "example.go")
+	if err := ioutil.WriteFile(path, []byte(exampleApacheGoSource), 0644); err != nil {
+		t.Errorf("WriteFile() error = %v", err)
+		return
+	}
+	got, err := DetectSourceFile(path)
+	if err != nil {
+		t.Errorf("DetectSourceFile() error = %v", err)
+		return
+	}
+	if got.LicenseID != "Apache-2.0" {
+		t.Errorf("DetectSourceFile() = %v, want %v", got.LicenseID, "Apache-2.0")
+	}
+
+	if _, err := DetectSourceFile(filepath.Join(dir, "missing.go")); err == nil {
+		t.Errorf("DetectSourceFile() of missing file doesn't return error")
+	}
+}
+
+func Test_extractHeader(t *testing.T) {
+	tests := []struct {
+		name     string
+		content  string
+		fileName string
+		want     string
+	}{
+		{
+			name:     "Line comments until code",
+			content:  "// Line one\n//\n//   Line two\n\npackage main\n// Not header",
+			fileName: "main.go",
+			want:     "Line one\n\nLine two",
+		},
+		{
+			name:     "Javadoc block",
+			content:  "/**\n * Line one\n * Line two\n */\npublic class Main {}",
+			fileName: "Main.java",
+			want:     "Line one\nLine two",
+		},
+		{
+			name:     "Hash comments after shebang",
+			content:  "#!/bin/sh\n# Line one\n## Line two\necho hi",
+			fileName: "run.sh",
+			want:     "Line one\nLine two",
+		},
+		{
+			name:     "Markup comment after declaration",
+			content:  "<?xml version=\"1.0\"?>\n<!--\n  Line one\n-->\n<root/>",
+			fileName: "pom.xml",
+			want:     "Line one",
+		},
+		{
+			name:     "Several blocks",
+			content:  "/* Line one */\n\n// Line two\nint main() {}",
+			fileName: "main.c",
+			want:     "Line one\n\nLine two",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := extractHeader(tt.content, findCommentStyle(tt.fileName)); got != tt.want {
+				t.Errorf("extractHeader() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -275,7 +275,7 @@ and hold each Contributor harmless for any liability incurred by, or claims
 asserted against, such Contributor by reason of your accepting any such warranty
 or additional liability. END OF TERMS AND CONDITIONS
 
-APPENDIX: How to apply the Apache License to your work.
+<<beginOptional>>APPENDIX: How to apply the Apache License to your work.
 
 To apply the Apache License to your work, attach the following boilerplate
 notice, with the fields enclosed by brackets "[]" replaced with your own identifying
@@ -303,4 +303,4 @@ WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
 
 See the License for the specific language governing permissions and
 
-limitations under the License.`
+limitations under the License.<<endOptional>>`
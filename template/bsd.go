@@ -1,6 +1,6 @@
 package template
 
-var bsd2Clause = `Copyright (c) <year> <owner> . All rights reserved.
+var bsd2Clause = `<<beginOptional>><<var;name="copyright";original="Copyright (c) <year> <owner>. All rights reserved.";match=".+?">><<endOptional>>
 
 Redistribution and use in source and binary forms, with or without modification,
 are permitted provided that the following conditions are met:
@@ -23,7 +23,7 @@ CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
 OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE
 USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.`
 
-var bsd3Clause = `Copyright (c) <year> <owner> . All rights reserved.
+var bsd3Clause = `<<beginOptional>><<var;name="copyright";original="Copyright (c) <year> <owner>. All rights reserved.";match=".+?">><<endOptional>>
 
 Redistribution and use in source and binary forms, with or without modification,
 are permitted provided that the following conditions are met:
@@ -50,11 +50,9 @@ CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
 OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE
 USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.`
 
-var bsd3ClauseClear = `The Clear BSD License
+var bsd3ClauseClear = `<<beginOptional>>The Clear BSD License<<endOptional>>
 
-Copyright (c) [xxxx]-[xxxx] [Owner Organization]
-
-All rights reserved.
+<<beginOptional>><<var;name="copyright";original="Copyright (c) [xxxx]-[xxxx] [Owner Organization]. All rights reserved.";match=".+?">><<endOptional>>
 
 Redistribution and use in source and binary forms, with or without modification,
 are permitted (subject to the limitations in the disclaimer below) provided
@@ -67,7 +65,7 @@ list of conditions and the following disclaimer.
 this list of conditions and the following disclaimer in the documentation
 and/or other materials provided with the distribution.
 
-* Neither the name of [Owner Organization] nor the names of its contributors
+* Neither the name of <<var;name="organization";original="[Owner Organization]";match=".+?">> nor the names of its contributors
 may be used to endorse or promote products derived from this software without
 specific prior written permission.
 
@@ -83,7 +81,7 @@ CAUSED AND ON ANY THEORY OF LIABILITY, WHETHER IN CONTRACT, STRICT LIABILITY,
 OR TORT (INCLUDING NEGLIGENCE OR OTHERWISE) ARISING IN ANY WAY OUT OF THE
 USE OF THIS SOFTWARE, EVEN IF ADVISED OF THE POSSIBILITY OF SUCH DAMAGE.`
 
-var bsd4Clause = `Copyright (c) <year> <owner> . All rights reserved.
+var bsd4Clause = `<<beginOptional>><<var;name="copyright";original="Copyright (c) <year> <owner>. All rights reserved.";match=".+?">><<endOptional>>
 
 Redistribution and use in source and binary forms, with or without modification,
 are permitted provided that the following conditions are met:
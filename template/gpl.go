@@ -263,7 +263,7 @@ OR A FAILURE OF THE PROGRAM TO OPERATE WITH ANY OTHER PROGRAMS), EVEN IF SUCH
 HOLDER OR OTHER PARTY HAS BEEN ADVISED OF THE POSSIBILITY OF SUCH DAMAGES.
 END OF TERMS AND CONDITIONS
 
-How to Apply These Terms to Your New Programs
+<<beginOptional>>How to Apply These Terms to Your New Programs
 
 If you develop a new program, and you want it to be of the greatest possible
 use to the public, the best way to achieve this is to make it free software
@@ -318,7 +318,7 @@ Public License does not permit incorporating your program into proprietary
 programs. If your program is a subroutine library, you may consider it more
 useful to permit linking proprietary applications with the library. If this
 is what you want to do, use the GNU Lesser General Public License instead
-of this License.`
+of this License.<<endOptional>>`
 
 var gnu30 = `GNU GENERAL PUBLIC LICENSE
 
@@ -892,7 +892,7 @@ liability in connection with the Program, unless a warranty or assumption
 of liability accompanies a copy of the Program in return for a fee. END OF
 TERMS AND CONDITIONS
 
-How to Apply These Terms to Your New Programs
+<<beginOptional>>How to Apply These Terms to Your New Programs
 
 If you develop a new program, and you want it to be of the greatest possible
 use to the public, the best way to achieve this is to make it free software
@@ -945,4 +945,4 @@ into proprietary programs. If your program is a subroutine library, you may
 consider it more useful to permit linking proprietary applications with the
 library. If this is what you want to do, use the GNU Lesser General Public
 License instead of this License. But first, please read <http s ://www.gnu.org/
-licenses /why-not-lgpl.html>.`
+licenses /why-not-lgpl.html>.<<endOptional>>`
@@ -0,0 +1,125 @@
+package template
+
+import (
+	"sort"
+	"sync"
+)
+
+// Headers are standard license notices of SPDX that are put into headers of source files instead of full license text,
+// by their SPDX license IDs. Copyright lines are skipped, source files have them in many forms.
+var Headers = map[string]string{
+	"Apache-2.0": apache20Header,
+
+	"GPL-2.0-or-later": gnu20Header,
+	"GPL-3.0-or-later": gnu30Header,
+
+	"LGPL-2.1-or-later": lgpl21Header,
+	"LGPL-3.0-or-later": lgpl30Header,
+
+	"MPL-2.0": mpl20Header,
+}
+
+var apache20Header = `Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.`
+
+var gnu20Header = `This program is free software; you can redistribute it and/or
+modify it under the terms of the GNU General Public License
+as published by the Free Software Foundation; either version 2
+of the License, or (at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program; if not, write to the Free Software
+Foundation, Inc., <<var;name="address";original="51 Franklin Street, Fifth Floor, Boston, MA 02110-1301";match=".+?">> USA.`
+
+var gnu30Header = `This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.`
+
+var lgpl21Header = `This library is free software; you can redistribute it and/or
+modify it under the terms of the GNU Lesser General Public
+License as published by the Free Software Foundation; either
+version 2.1 of the License, or (at your option) any later version.
+
+This library is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the GNU
+Lesser General Public License for more details.
+
+You should have received a copy of the GNU Lesser General Public
+License along with this library; if not, write to the Free Software
+Foundation, Inc., <<var;name="address";original="51 Franklin Street, Fifth Floor, Boston, MA 02110-1301";match=".+?">> USA`
+
+var lgpl30Header = `This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Lesser General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Lesser General Public License for more details.
+
+You should have received a copy of the GNU Lesser General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.`
+
+var mpl20Header = `This Source Code Form is subject to the terms of the Mozilla Public
+License, v. 2.0. If a copy of the MPL was not distributed with this
+file, You can obtain one at http://mozilla.org/MPL/2.0/.`
+
+var (
+	headerMarkupsOnce sync.Once
+	headerMarkups     map[string]*Markup
+	headerLicenseIDs  []string
+)
+
+// parsedHeaderMarkups parses all Headers once. Headers are static, so invalid markup is a programming error.
+func parsedHeaderMarkups() (map[string]*Markup, []string) {
+	headerMarkupsOnce.Do(func() {
+		headerMarkups = make(map[string]*Markup, len(Headers))
+		for licenseID, header := range Headers {
+			m, err := Parse(header)
+			if err != nil {
+				panic("header '" + licenseID + "': " + err.Error())
+			}
+			headerMarkups[licenseID] = m
+			headerLicenseIDs = append(headerLicenseIDs, licenseID)
+		}
+		sort.Strings(headerLicenseIDs)
+	})
+	return headerMarkups, headerLicenseIDs
+}
+
+// MatchHeader finds the standard license header that the text contains. It returns SPDX license ID of the header
+// and captured values of its variables.
+func MatchHeader(text string) (string, map[string]string, bool) {
+	markups, licenseIDs := parsedHeaderMarkups()
+	for _, licenseID := range licenseIDs {
+		if variables, ok := markups[licenseID].Find(text); ok {
+			return licenseID, variables, true
+		}
+	}
+	return "", nil, false
+}
@@ -0,0 +1,74 @@
+package template
+
+import (
+	"testing"
+)
+
+func TestMatchHeader(t *testing.T) {
+	tests := []struct {
+		name      string
+		text      string
+		licenseID string
+		matched   bool
+	}{
+		{
+			name: "Apache header with copyright",
+			text: `Copyright 2019 The Example Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+     https://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.`,
+			licenseID: "Apache-2.0",
+			matched:   true,
+		},
+		{
+			name: "GPL header with old address",
+			text: `This program is free software; you can redistribute it and/or
+modify it under the terms of the GNU General Public License
+as published by the Free Software Foundation; either version 2
+of the License, or (at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program; if not, write to the Free Software
+Foundation, Inc., 59 Temple Place, Suite 330, Boston, MA 02111-1307 USA.`,
+			licenseID: "GPL-2.0-or-later",
+			matched:   true,
+		},
+		{
+			name:      "MPL header",
+			text:      "This Source Code Form is subject to the terms of the Mozilla Public License, v. 2.0. If a copy of the MPL was not distributed with this file, You can obtain one at https://mozilla.org/MPL/2.0/.",
+			licenseID: "MPL-2.0",
+			matched:   true,
+		},
+		{
+			name:    "Not a header",
+			text:    "Package main is an example.",
+			matched: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			licenseID, _, matched := MatchHeader(tt.text)
+			if matched != tt.matched {
+				t.Errorf("MatchHeader() matched = %v, want %v", matched, tt.matched)
+				return
+			}
+			if licenseID != tt.licenseID {
+				t.Errorf("MatchHeader() licenseID = %v, want %v", licenseID, tt.licenseID)
+			}
+		})
+	}
+}
@@ -400,7 +400,7 @@ OR A FAILURE OF THE LIBRARY TO OPERATE WITH ANY OTHER SOFTWARE), EVEN IF SUCH
 HOLDER OR OTHER PARTY HAS BEEN ADVISED OF THE POSSIBILITY OF SUCH DAMAGES.
 END OF TERMS AND CONDITIONS
 
-How to Apply These Terms to Your New Libraries
+<<beginOptional>>How to Apply These Terms to Your New Libraries
 
 If you develop a new library, and you want it to be of the greatest possible
 use to the public, we recommend making it free software that everyone can
@@ -447,7 +447,7 @@ signature of Ty Coon, 1 April 1990
 
 Ty Coon, President of Vice
 
-That's all there is to it!`
+That's all there is to it!<<endOptional>>`
 
 var lgpl21 = `GNU LESSER GENERAL PUBLIC LICENSE
 
@@ -869,7 +869,7 @@ OR A FAILURE OF THE LIBRARY TO OPERATE WITH ANY OTHER SOFTWARE), EVEN IF SUCH
 HOLDER OR OTHER PARTY HAS BEEN ADVISED OF THE POSSIBILITY OF SUCH DAMAGES.
 END OF TERMS AND CONDITIONS
 
-How to Apply These Terms to Your New Libraries
+<<beginOptional>>How to Apply These Terms to Your New Libraries
 
 If you develop a new library, and you want it to be of the greatest possible
 use to the public, we recommend making it free software that everyone can
@@ -916,7 +916,7 @@ by James Random Hacker.
 
 Ty Coon, President of Vice
 
-That's all there is to it!`
+That's all there is to it!<<endOptional>>`
 
 var lgpl30 = `GNU LESSER GENERAL PUBLIC LICENSE
 
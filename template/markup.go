@@ -0,0 +1,261 @@
+package template
+
+import (
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/ledongthuc/licensechecker/normalize"
+	"github.com/pkg/errors"
+)
+
+var (
+	ErrorInvalidMarkup = errors.New("Invalid license template markup")
+)
+
+// matchSteps are normalization steps applied before matching markup. Copyright notice and case are kept
+// so captured variables still have their original values.
+const matchSteps = normalize.AllSteps &^ (normalize.StepCopyrightNotice | normalize.StepCase)
+
+// Separators use ASCII ranges instead of unicode classes and aren't case insensitive, because they're repeated
+// for every word and folding a big class is expensive to compile. Non-ASCII punctuation is already unified by normalization.
+const (
+	// separatorPattern matches anything between 2 words
+	separatorPattern = `[^0-9A-Za-z\x{80}-\x{10FFFF}]+`
+	// optionalSeparatorPattern matches anything between 2 segments
+	optionalSeparatorPattern = `[^0-9A-Za-z\x{80}-\x{10FFFF}]*`
+	// fallbackVarPattern is used when a variable has no or invalid match pattern
+	fallbackVarPattern = `.*?`
+	// openingPunctuation and closingPunctuation enclose values of variables, they aren't words of normalized text
+	openingPunctuation = `<([{"'`
+	closingPunctuation = `>)]}"'`
+)
+
+var (
+	tagPattern        = regexp.MustCompile(`<<(.*?)>>`)
+	tagFieldPattern   = regexp.MustCompile(`(\w+)\s*=\s*"((?:[^"\\]|\\.)*)"`)
+	markupWordPattern = regexp.MustCompile(`[\p{L}\p{N}]+`)
+)
+
+// SegmentType defines kinds of segment in license template markup
+type SegmentType int
+
+const (
+	// SegmentText is a fixed text that must exist
+	SegmentText SegmentType = iota
+	// SegmentVar is a variable text that is matched by a regular expression, e.g. copyright holder and year
+	SegmentVar
+	// SegmentOptional contains segments that can be absent
+	SegmentOptional
+)
+
+// Segment is a part of license template markup
+type Segment struct {
+	Type SegmentType
+	// Text is the fixed text of SegmentText
+	Text string
+	// Name, Original and Match are attributes of SegmentVar
+	Name     string
+	Original string
+	Match    string
+	// Children are segments inside of SegmentOptional
+	Children []Segment
+}
+
+// Markup is a parsed SPDX license template, which contains <<var;name=...;original=...;match=...>>
+// and <<beginOptional>>...<<endOptional>> tags.
+type Markup struct {
+	Segments []Segment
+
+	pattern *regexp.Regexp
+	// anchored matches the whole text, punctuation and whitespace around license are allowed
+	anchored   *regexp.Regexp
+	varNames   []string
+	varIndexes []int
+}
+
+// Parse parses SPDX license template markup and prepares it for matching
+func Parse(markup string) (*Markup, error) {
+	stack := [][]Segment{{}}
+	position := 0
+	for _, loc := range tagPattern.FindAllStringSubmatchIndex(markup, -1) {
+		if loc[0] > position {
+			stack[len(stack)-1] = append(stack[len(stack)-1], Segment{Type: SegmentText, Text: markup[position:loc[0]]})
+		}
+		position = loc[1]
+
+		tag := strings.TrimSpace(markup[loc[2]:loc[3]])
+		kind := tag
+		if index := strings.Index(tag, ";"); index >= 0 {
+			kind = tag[:index]
+		}
+		switch strings.TrimSpace(kind) {
+		case "var":
+			segment := Segment{Type: SegmentVar}
+			for _, field := range tagFieldPattern.FindAllStringSubmatch(tag, -1) {
+				value := strings.Replace(field[2], `\"`, `"`, -1)
+				switch field[1] {
+				case "name":
+					segment.Name = value
+				case "original":
+					segment.Original = value
+				case "match":
+					segment.Match = value
+				}
+			}
+			stack[len(stack)-1] = append(stack[len(stack)-1], segment)
+		case "beginOptional":
+			stack = append(stack, []Segment{})
+		case "endOptional":
+			if len(stack) < 2 {
+				return nil, errors.Wrap(ErrorInvalidMarkup, "endOptional without beginOptional")
+			}
+			children := stack[len(stack)-1]
+			stack = stack[:len(stack)-1]
+			stack[len(stack)-1] = append(stack[len(stack)-1], Segment{Type: SegmentOptional, Children: children})
+		default:
+			return nil, errors.Wrap(ErrorInvalidMarkup, "unknown tag '"+tag+"'")
+		}
+	}
+	if len(stack) != 1 {
+		return nil, errors.Wrap(ErrorInvalidMarkup, "beginOptional without endOptional")
+	}
+	if position < len(markup) {
+		stack[0] = append(stack[0], Segment{Type: SegmentText, Text: markup[position:]})
+	}
+
+	m := &Markup{Segments: stack[0]}
+	compiled := m.compile(m.Segments)
+	pattern, err := regexp.Compile(`(?s)` + compiled)
+	if err != nil {
+		return nil, errors.Wrap(ErrorInvalidMarkup, err.Error())
+	}
+	m.pattern = pattern
+	m.anchored = regexp.MustCompile(`(?s)^` + optionalSeparatorPattern + `(?:` + compiled + `)` + optionalSeparatorPattern + `$`)
+	m.varIndexes = make([]int, len(m.varNames))
+	for index, name := range pattern.SubexpNames() {
+		if strings.HasPrefix(name, "v") {
+			if varIndex, err := strconv.Atoi(name[1:]); err == nil {
+				m.varIndexes[varIndex] = index
+			}
+		}
+	}
+	return m, nil
+}
+
+// compile builds regular expression of segments. Words of fixed text and variables are matched case insensitively,
+// words are separated by any punctuation or whitespace.
+func (m *Markup) compile(segments []Segment) string {
+	parts := make([]string, 0, len(segments))
+	for _, segment := range segments {
+		switch segment.Type {
+		case SegmentText:
+			words := markupWordPattern.FindAllString(normalize.TextWithSteps(segment.Text, matchSteps), -1)
+			if len(words) == 0 {
+				continue
+			}
+			for index, word := range words {
+				words[index] = `(?i:` + regexp.QuoteMeta(word) + `)`
+			}
+			parts = append(parts, strings.Join(words, separatorPattern))
+		case SegmentVar:
+			match := segment.Match
+			if _, err := regexp.Compile(match); err != nil || match == "" {
+				match = fallbackVarPattern
+			}
+			m.varNames = append(m.varNames, segment.Name)
+			parts = append(parts, `(?P<v`+strconv.Itoa(len(m.varNames)-1)+`>(?i:`+match+`))`)
+		case SegmentOptional:
+			if children := m.compile(segment.Children); children != "" {
+				parts = append(parts, `(?:`+children+`)?`)
+			}
+		}
+	}
+	return strings.Join(parts, optionalSeparatorPattern)
+}
+
+// Original returns license text of markup with original values of variables and with optional segments
+func (m *Markup) Original() string {
+	return original(m.Segments)
+}
+
+func original(segments []Segment) string {
+	var builder strings.Builder
+	for _, segment := range segments {
+		switch segment.Type {
+		case SegmentText:
+			builder.WriteString(segment.Text)
+		case SegmentVar:
+			builder.WriteString(segment.Original)
+		case SegmentOptional:
+			builder.WriteString(original(segment.Children))
+		}
+	}
+	return builder.String()
+}
+
+// Match checks whether the whole text is license of markup, only punctuation and whitespace can be around it. It
+// returns captured values of variables by their names, values are original text, e.g. "Copyright (c) 2019" instead of
+// its normalized form.
+func (m *Markup) Match(text string) (map[string]string, bool) {
+	return m.match(m.anchored, text)
+}
+
+// Find checks whether the text contains license of markup anywhere, e.g. a license header among other comments.
+// It returns captured values of variables like Match.
+func (m *Markup) Find(text string) (map[string]string, bool) {
+	return m.match(m.pattern, text)
+}
+
+func (m *Markup) match(pattern *regexp.Regexp, text string) (map[string]string, bool) {
+	normalized := normalize.TextWithSteps(text, matchSteps)
+	submatches := pattern.FindStringSubmatchIndex(normalized)
+	if submatches == nil {
+		return nil, false
+	}
+
+	words := markupWordPattern.FindAllStringIndex(normalized, -1)
+	tokens := normalize.TokenizeWithSteps(text, matchSteps)
+	variables := make(map[string]string, len(m.varNames))
+	for index, name := range m.varNames {
+		// Groups of both patterns have the same indexes, the anchored one only wraps them into a non-capturing group
+		group := m.varIndexes[index]
+		start, end := submatches[2*group], submatches[2*group+1]
+		if name == "" || start < 0 {
+			continue
+		}
+		if value := originalValue(text, normalized, start, end, words, tokens); value != "" {
+			variables[name] = value
+		}
+	}
+	return variables, true
+}
+
+// originalValue maps range [start, end) of normalized text back into text by its first and last words. Words of
+// normalized text are tokens of text one by one, normalized value is returned if they aren't. Brackets and quotes
+// that enclose the value in text are kept, e.g. "<copyright holders>".
+func originalValue(text, normalized string, start, end int, words [][]int, tokens []normalize.Word) string {
+	value := strings.TrimSpace(normalized[start:end])
+	if len(words) != len(tokens) {
+		return value
+	}
+	first := sort.Search(len(words), func(i int) bool {
+		return words[i][0] >= start
+	})
+	last := sort.Search(len(words), func(i int) bool {
+		return words[i][1] > end
+	})
+	if first >= last {
+		return value
+	}
+
+	originalStart, originalEnd := tokens[first].Start, tokens[last-1].End
+	for originalStart > 0 && (first == 0 || originalStart > tokens[first-1].End) && strings.IndexByte(openingPunctuation, text[originalStart-1]) >= 0 {
+		originalStart--
+	}
+	for originalEnd < len(text) && (last == len(tokens) || originalEnd < tokens[last].Start) && strings.IndexByte(closingPunctuation, text[originalEnd]) >= 0 {
+		originalEnd++
+	}
+	return strings.TrimSpace(text[originalStart:originalEnd])
+}
@@ -0,0 +1,171 @@
+package template
+
+import (
+	"reflect"
+	"testing"
+)
+
+const exampleMarkup = `<<beginOptional>>Example License<<endOptional>>
+
+Copyright (c) <<var;name="year";original="<year>";match="[0-9]{4}">> <<var;name="holder";original="<owner>";match=".+?">>
+
+Permission is granted to "use" this software.<<beginOptional>> Attribution is appreciated.<<endOptional>>
+THE SOFTWARE IS PROVIDED AS IS.`
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name     string
+		markup   string
+		original string
+		wantErr  bool
+	}{
+		{
+			name:     "Plain text",
+			markup:   "Permission is granted.",
+			original: "Permission is granted.",
+		},
+		{
+			name:   "Variables and optional segments",
+			markup: exampleMarkup,
+			original: `Example License
+
+Copyright (c) <year> <owner>
+
+Permission is granted to "use" this software. Attribution is appreciated.
+THE SOFTWARE IS PROVIDED AS IS.`,
+		},
+		{
+			name:    "Unknown tag",
+			markup:  "Permission <<unknown>> is granted.",
+			wantErr: true,
+		},
+		{
+			name:    "Missing endOptional",
+			markup:  "<<beginOptional>>Permission is granted.",
+			wantErr: true,
+		},
+		{
+			name:    "Missing beginOptional",
+			markup:  "Permission is granted.<<endOptional>>",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.markup)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Parse() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if err != nil {
+				return
+			}
+			if original := got.Original(); original != tt.original {
+				t.Errorf("Parse().Original() = %q, want %q", original, tt.original)
+			}
+		})
+	}
+}
+
+func TestMarkup_Match(t *testing.T) {
+	m, err := Parse(exampleMarkup)
+	if err != nil {
+		t.Errorf("Parse() error = %v", err)
+		return
+	}
+
+	tests := []struct {
+		name      string
+		text      string
+		variables map[string]string
+		matched   bool
+	}{
+		{
+			name: "Full text",
+			text: `Example License
+
+Copyright (c) 2019 Thuc Le
+
+Permission is granted to “use” this software. Attribution is appreciated.
+THE SOFTWARE IS PROVIDED AS IS.`,
+			variables: map[string]string{"year": "2019", "holder": "Thuc Le"},
+			matched:   true,
+		},
+		{
+			name:      "Optional segments are absent",
+			text:      "Copyright © 2019 Thuc Le. Permission is granted to 'use' this software. The software is provided as is.",
+			variables: map[string]string{"year": "2019", "holder": "Thuc Le"},
+			matched:   true,
+		},
+		{
+			name:      "Variables keep original text",
+			text:      "COPYRIGHT (C) 2019 Thuc Le — Sub-Licence Team. Permission is granted to use this software. The software is provided as is.",
+			variables: map[string]string{"year": "2019", "holder": "Thuc Le — Sub-Licence Team"},
+			matched:   true,
+		},
+		{
+			name:    "Variable doesn't match its pattern",
+			text:    "Copyright (c) last year Thuc Le. Permission is granted to use this software. The software is provided as is.",
+			matched: false,
+		},
+		{
+			name:    "Required text is missing",
+			text:    "Copyright (c) 2019 Thuc Le. Permission is granted to use this software.",
+			matched: false,
+		},
+		{
+			name:    "Text after license",
+			text:    "Copyright (c) 2019 Thuc Le. Permission is granted to use this software. The software is provided as is. You may not sell the software.",
+			matched: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			variables, matched := m.Match(tt.text)
+			if matched != tt.matched {
+				t.Errorf("Match() matched = %v, want %v", matched, tt.matched)
+				return
+			}
+			if matched && !reflect.DeepEqual(variables, tt.variables) {
+				t.Errorf("Match() variables = %v, want %v", variables, tt.variables)
+			}
+		})
+	}
+}
+
+func TestMarkup_Find(t *testing.T) {
+	m, err := Parse(exampleMarkup)
+	if err != nil {
+		t.Errorf("Parse() error = %v", err)
+		return
+	}
+	text := "// Copyright (c) 2019 Thuc Le. Permission is granted to use this software. The software is provided as is.\npackage main"
+	variables, found := m.Find(text)
+	if want := map[string]string{"year": "2019", "holder": "Thuc Le"}; !found || !reflect.DeepEqual(variables, want) {
+		t.Errorf("Find() = %v, %v, want %v, true", variables, found, want)
+	}
+}
+
+func TestMarkup_Match_original(t *testing.T) {
+	tests := []struct {
+		licenseID string
+		name      string
+		want      string
+	}{
+		{licenseID: "MIT", name: "copyright", want: "Copyright (c) <year> <copyright holders>"},
+		{licenseID: "BSD-3-Clause", name: "copyright", want: "Copyright (c) <year> <owner>. All rights reserved"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.licenseID, func(t *testing.T) {
+			m, ok := FindByLicenseID(tt.licenseID)
+			if !ok {
+				t.Errorf("FindByLicenseID() isn't found")
+				return
+			}
+			variables, matched := m.Match(m.Original())
+			if !matched || variables[tt.name] != tt.want {
+				t.Errorf("Match() = %v, %v, want %s %q", variables, matched, tt.name, tt.want)
+			}
+		})
+	}
+}
@@ -1,8 +1,8 @@
 package template
 
-var mit = `MIT License
+var mit = `<<beginOptional>>MIT License<<endOptional>>
 
-Copyright (c) <year> <copyright holders>
+<<beginOptional>><<var;name="copyright";original="Copyright (c) <year> <copyright holders>";match=".+?">><<endOptional>>
 
 Permission is hereby granted, free of charge, to any person obtaining a copy
 of this software and associated documentation files (the "Software"), to deal
@@ -11,8 +11,8 @@ to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
 copies of the Software, and to permit persons to whom the Software is furnished
 to do so, subject to the following conditions:
 
-The above copyright notice and this permission notice (including the next
-paragraph) shall be included in all copies or substantial portions of the
+The above copyright notice and this permission notice <<beginOptional>>(including the next
+paragraph)<<endOptional>> shall be included in all copies or substantial portions of the
 Software.
 
 THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
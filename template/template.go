@@ -2,73 +2,147 @@ package template
 
 import (
 	"math"
-	"regexp"
+	"sort"
+	"sync"
 
 	"github.com/agnivade/levenshtein"
+	"github.com/ledongthuc/licensechecker/normalize"
 )
 
-var Templates = map[string]string{
-	"Apache License 1.0": Apache10,
-	"Apache License 1.1": Apache11,
-	"Apache License 2.0": Apache20,
+var (
+	// spdxTemplates are SPDX license templates by license IDs. They're generated into spdx_templates.go from SPDX license
+	// list data with "make update", and are empty until then.
+	spdxTemplates = map[string]string{}
 
-	"BSD 2-Clause 'Simplified' License":       bsd2Clause,
-	"BSD 3-Clause 'New' or 'Revised' License": bsd3Clause,
-	"BSD 3-Clause Clear License":              bsd3ClauseClear,
+	// staticTemplates are hand-written SPDX templates by SPDX license IDs, they're used for licenses without generated
+	// template. Scopes and deprecated IDs of GNU licenses share the same template.
+	staticTemplates = map[string]string{
+		"Apache-1.0": Apache10,
+		"Apache-1.1": Apache11,
+		"Apache-2.0": Apache20,
 
-	"Creative Commons Attribution 4.0": ccBy40,
+		"BSD-2-Clause":       bsd2Clause,
+		"BSD-3-Clause":       bsd3Clause,
+		"BSD-3-Clause-Clear": bsd3ClauseClear,
+		"BSD-4-Clause":       bsd4Clause,
 
-	"GNU General Public License v2.0": gnu20,
-	"GNU General Public License v3.0": gnu30,
+		"CC-BY-4.0": ccBy40,
 
-	"GNU Lesser General Public License v2.0": lgpl20,
-	"GNU Lesser General Public License v2.1": lgpl21,
-	"GNU Lesser General Public License v3.0": lgpl30,
+		"GPL-2.0":          gnu20,
+		"GPL-2.0+":         gnu20,
+		"GPL-2.0-only":     gnu20,
+		"GPL-2.0-or-later": gnu20,
+		"GPL-3.0":          gnu30,
+		"GPL-3.0+":         gnu30,
+		"GPL-3.0-only":     gnu30,
+		"GPL-3.0-or-later": gnu30,
 
-	"MIT License": mit,
+		"LGPL-2.0":          lgpl20,
+		"LGPL-2.0+":         lgpl20,
+		"LGPL-2.0-only":     lgpl20,
+		"LGPL-2.0-or-later": lgpl20,
+		"LGPL-2.1":          lgpl21,
+		"LGPL-2.1+":         lgpl21,
+		"LGPL-2.1-only":     lgpl21,
+		"LGPL-2.1-or-later": lgpl21,
+		"LGPL-3.0":          lgpl30,
+		"LGPL-3.0+":         lgpl30,
+		"LGPL-3.0-only":     lgpl30,
+		"LGPL-3.0-or-later": lgpl30,
 
-	"ISC License": isc,
+		"MIT": mit,
+
+		"ISC": isc,
+
+		"MPL-2.0": mpl20,
+	}
+)
+
+var (
+	markupsMutex sync.Mutex
+	// markups caches parsed templates by SPDX license IDs, nil is cached for invalid templates
+	markups = map[string]*Markup{}
+)
+
+// LicenseIDs returns sorted SPDX IDs of all licenses and exceptions that have template. SPDX templates have the same
+// IDs as licenses of AllInfo(), hand-written templates are used for the rest.
+func LicenseIDs() []string {
+	licenseIDs := make([]string, 0, len(spdxTemplates)+len(staticTemplates))
+	for licenseID := range spdxTemplates {
+		licenseIDs = append(licenseIDs, licenseID)
+	}
+	for licenseID := range staticTemplates {
+		if _, ok := spdxTemplates[licenseID]; !ok {
+			licenseIDs = append(licenseIDs, licenseID)
+		}
+	}
+	sort.Strings(licenseIDs)
+	return licenseIDs
 }
 
+// FindByLicenseID returns parsed template of SPDX license ID, SPDX template is preferred over hand-written one.
+// Templates are parsed once, when they're used first time. A template that isn't valid markup is handled as missing.
+func FindByLicenseID(licenseID string) (*Markup, bool) {
+	markupsMutex.Lock()
+	defer markupsMutex.Unlock()
+
+	if m, ok := markups[licenseID]; ok {
+		return m, m != nil
+	}
+	content, ok := spdxTemplates[licenseID]
+	if !ok {
+		content, ok = staticTemplates[licenseID]
+	}
+	var m *Markup
+	if ok {
+		if parsed, err := Parse(content); err == nil {
+			m = parsed
+		}
+	}
+	markups[licenseID] = m
+	return m, m != nil
+}
+
+// FindMatchName returns SPDX license ID of the template that is the closest to license by Levenshtein distance,
+// and the distance. Distance of 2 texts is at least difference of their lengths, so templates are compared from the
+// closest length and skipped once the difference isn't lower than the best distance.
 func FindMatchName(license string) (string, int) {
-	// var matchName string
-	// var matchPercent float64
-	// for name, template := range Templates {
-	// 	p := smetrics.JaroWinkler(license, template, 0.7, 4)
-	// 	if p > matchPercent {
-	// 		matchName = name
-	// 		matchPercent = p
-	// 	}
-	// }
-	// return matchName, matchPercent
-
-	// dmp := diffmatchpatch.New()
-	// var matchName string
-	// noDiff := int(math.MaxInt32)
-	// for name, template := range Templates {
-	// 	diffs := dmp.DiffMain(license, template, false)
-	// 	if len(diffs) < noDiff {
-	// 		matchName = name
-	// 		noDiff = len(diffs)
-	// 	}
-	// }
-	// return matchName, 1.0
-
-	// Need to replace by preformated
-	license = regexp.MustCompile(`\r?\n`).ReplaceAllString(license, " ")
+	license = normalize.Text(license)
+
+	type candidate struct {
+		licenseID string
+		text      string
+	}
+	candidates := []candidate{}
+	for _, licenseID := range LicenseIDs() {
+		if m, ok := FindByLicenseID(licenseID); ok {
+			candidates = append(candidates, candidate{licenseID: licenseID, text: normalize.Text(m.Original())})
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		return lengthDifference(license, candidates[i].text) < lengthDifference(license, candidates[j].text)
+	})
 
 	var matchName string
 	var distance = int(math.MaxInt32)
-	for name, template := range Templates {
-
-		// Need to replace by preformated
-		template = regexp.MustCompile(`\r?\n`).ReplaceAllString(template, " ")
-
-		d := levenshtein.ComputeDistance(license, template)
+	for _, c := range candidates {
+		if lengthDifference(license, c.text) >= distance {
+			break
+		}
+		d := levenshtein.ComputeDistance(license, c.text)
 		if d < distance {
-			matchName = name
+			matchName = c.licenseID
 			distance = d
 		}
 	}
 	return matchName, distance
 }
+
+// lengthDifference is the lowest Levenshtein distance that 2 texts can have
+func lengthDifference(a, b string) int {
+	difference := len([]rune(a)) - len([]rune(b))
+	if difference < 0 {
+		return -difference
+	}
+	return difference
+}
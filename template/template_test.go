@@ -0,0 +1,55 @@
+package template
+
+import (
+	"testing"
+)
+
+func TestTemplatesAreValid(t *testing.T) {
+	licenseIDs := LicenseIDs()
+	if len(licenseIDs) == 0 {
+		t.Errorf("LicenseIDs() doesn't have any template")
+	}
+	for _, licenseID := range licenseIDs {
+		if _, ok := FindByLicenseID(licenseID); !ok {
+			t.Errorf("FindByLicenseID(%s) template isn't valid", licenseID)
+		}
+	}
+}
+
+func TestStaticTemplates(t *testing.T) {
+	for licenseID, content := range staticTemplates {
+		if _, err := Parse(content); err != nil {
+			t.Errorf("Parse() hand-written template of %s error = %v", licenseID, err)
+		}
+		if _, ok := FindByLicenseID(licenseID); !ok {
+			t.Errorf("FindByLicenseID(%s) isn't found", licenseID)
+		}
+	}
+}
+
+func TestFindByLicenseID(t *testing.T) {
+	tests := []struct {
+		licenseID string
+		found     bool
+	}{
+		{licenseID: "MIT", found: true},
+		{licenseID: "Apache-2.0", found: true},
+		{licenseID: "MPL-2.0", found: true},
+		{licenseID: "GPL-2.0-or-later", found: true},
+		{licenseID: "MIT License", found: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.licenseID, func(t *testing.T) {
+			if _, found := FindByLicenseID(tt.licenseID); found != tt.found {
+				t.Errorf("FindByLicenseID() found = %v, want %v", found, tt.found)
+			}
+		})
+	}
+}
+
+func TestFindMatchName(t *testing.T) {
+	m, _ := FindByLicenseID("MIT")
+	if got, _ := FindMatchName(m.Original()); got != "MIT" {
+		t.Errorf("FindMatchName() = %v, want MIT", got)
+	}
+}
@@ -0,0 +1,99 @@
+package licensechecker
+
+import (
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var (
+	// referenceURLPattern matches URLs in text, with a scheme, a "www." host, or a host and path, e.g. "opensource.org/licenses/MIT"
+	referenceURLPattern = regexp.MustCompile(`(?i)(?:\bhttps?://|\bwww\.|\b[a-z0-9-]+(?:\.[a-z0-9-]+)*\.[a-z]{2,}/)[^\s<>"'()\[\]{}]+`)
+	// urlSchemePattern matches scheme and "www." prefix of URL, which don't change the page
+	urlSchemePattern = regexp.MustCompile(`(?i)^(?:[a-z][a-z0-9+.-]*://)?(?:www\.)?`)
+)
+
+// ReferencedURL is a URL at byte range [Start, End) of content, and the licenses that have it as reference
+type ReferencedURL struct {
+	URL        string
+	Start, End int
+	// Licenses are ordered by ID, current licenses go before deprecated ones
+	Licenses []LicenseInfo
+}
+
+// LookupByURL finds licenses and exceptions that have the URL as reference, e.g. "http://opensource.org/licenses/MIT/"
+// finds MIT. Scheme, "www." prefix, case of host and trailing slash of URL are ignored. Current licenses go before
+// deprecated ones, then they're ordered by ID.
+func LookupByURL(url string) ([]LicenseInfo, error) {
+	c, err := loadCorpus()
+	if err != nil {
+		return []LicenseInfo{}, err
+	}
+	return c.lookupURL(url), nil
+}
+
+// newURLIndex maps normalized references of licenses and exceptions into their entries
+func newURLIndex(entries []corpusEntry) map[string][]int {
+	urls := make(map[string][]int)
+	for entryIndex, entry := range entries {
+		for _, reference := range entry.info.References {
+			key := urlKey(reference)
+			if key == "" {
+				continue
+			}
+			if indexes := urls[key]; len(indexes) > 0 && indexes[len(indexes)-1] == entryIndex {
+				continue
+			}
+			urls[key] = append(urls[key], entryIndex)
+		}
+	}
+	return urls
+}
+
+// urlKey normalizes URL for lookup. Scheme, "www." prefix, case of host, fragment and trailing slash are ignored, so
+// "https://www.Apache.org/licenses/LICENSE-2.0/" and "http://apache.org/licenses/LICENSE-2.0" have the same key.
+func urlKey(url string) string {
+	url = urlSchemePattern.ReplaceAllString(strings.TrimSpace(url), "")
+	if index := strings.Index(url, "#"); index >= 0 {
+		url = url[:index]
+	}
+	url = strings.TrimRight(url, "/")
+	host, path := url, ""
+	if index := strings.Index(url, "/"); index >= 0 {
+		host, path = url[:index], url[index:]
+	}
+	return strings.ToLower(host) + path
+}
+
+// lookupURL finds licenses and exceptions that have the URL as reference
+func (c corpus) lookupURL(url string) []LicenseInfo {
+	indexes := c.urls[urlKey(url)]
+	result := make([]LicenseInfo, 0, len(indexes))
+	for _, entryIndex := range indexes {
+		result = append(result, c.entries[entryIndex].info)
+	}
+	sort.SliceStable(result, func(i, j int) bool {
+		return preferredOver(result[i], result[j])
+	})
+	return result
+}
+
+// findReferencedURLs finds URLs in content that are references of licenses. Punctuation at the end of a URL is
+// handled as the end of sentence.
+func (c corpus) findReferencedURLs(content []byte) []ReferencedURL {
+	result := []ReferencedURL{}
+	for _, match := range referenceURLPattern.FindAllIndex(content, -1) {
+		url := strings.TrimRight(string(content[match[0]:match[1]]), ".,;:!?*`")
+		licenses := c.lookupURL(url)
+		if len(licenses) == 0 {
+			continue
+		}
+		result = append(result, ReferencedURL{
+			URL:      url,
+			Start:    match[0],
+			End:      match[0] + len(url),
+			Licenses: licenses,
+		})
+	}
+	return result
+}
@@ -0,0 +1,87 @@
+package licensechecker
+
+import (
+	"reflect"
+	"testing"
+)
+
+func Test_urlKey(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{url: "https://opensource.org/licenses/MIT", want: "opensource.org/licenses/MIT"},
+		{url: "http://www.Apache.org/licenses/LICENSE-2.0/", want: "apache.org/licenses/LICENSE-2.0"},
+		{url: "www.gnu.org/licenses/gpl-3.0.html#license-text", want: "gnu.org/licenses/gpl-3.0.html"},
+		{url: " opensource.org/licenses/MIT ", want: "opensource.org/licenses/MIT"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			if got := urlKey(tt.url); got != tt.want {
+				t.Errorf("urlKey() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLookupByURL(t *testing.T) {
+	tests := []struct {
+		url        string
+		licenseIDs []string
+	}{
+		{url: "http://opensource.org/licenses/MIT/", licenseIDs: []string{"MIT"}},
+		{url: "https://www.apache.org/licenses/LICENSE-2.0", licenseIDs: []string{"Apache-2.0"}},
+		{url: "http://www.gnu.org/licenses/old-licenses/gpl-2.0-standalone.html", licenseIDs: []string{"GPL-2.0-only", "GPL-2.0-or-later"}},
+		{url: "https://example.com/license", licenseIDs: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			got, err := LookupByURL(tt.url)
+			if err != nil {
+				t.Errorf("LookupByURL() error = %v", err)
+				return
+			}
+			licenseIDs := []string{}
+			for _, info := range got {
+				if !info.IsDeprecated {
+					licenseIDs = append(licenseIDs, info.LicenseID)
+				}
+			}
+			if !reflect.DeepEqual(licenseIDs, tt.licenseIDs) {
+				t.Errorf("LookupByURL() = %v, want %v", licenseIDs, tt.licenseIDs)
+			}
+			for index := 1; index < len(got); index++ {
+				if preferredOver(got[index], got[index-1]) {
+					t.Errorf("LookupByURL() has deprecated %v before %v", got[index-1].LicenseID, got[index].LicenseID)
+				}
+			}
+		})
+	}
+}
+
+func TestDetect_referencedURLs(t *testing.T) {
+	content := exampleMITContent + "\n\nSee https://opensource.org/licenses/MIT. Parts are from http://www.apache.org/licenses/LICENSE-2.0, and https://example.com/."
+	got, err := DetectWithOptions([]byte(content), DetectOptions{})
+	if err != nil {
+		t.Errorf("DetectWithOptions() error = %v", err)
+		return
+	}
+	if len(got.ReferencedURLs) != 2 {
+		t.Errorf("DetectWithOptions() ReferencedURLs = %+v, want 2 URLs", got.ReferencedURLs)
+		return
+	}
+	for index, want := range []struct{ url, licenseID string }{
+		{url: "https://opensource.org/licenses/MIT", licenseID: "MIT"},
+		{url: "http://www.apache.org/licenses/LICENSE-2.0", licenseID: "Apache-2.0"},
+	} {
+		reference := got.ReferencedURLs[index]
+		if reference.URL != want.url || content[reference.Start:reference.End] != want.url || reference.Licenses[0].LicenseID != want.licenseID {
+			t.Errorf("ReferencedURLs[%d] = %+v, want %v of %v", index, reference, want.url, want.licenseID)
+		}
+	}
+
+	got, err = DetectWithOptions([]byte("Licensed under https://opensource.org/licenses/MIT"), DetectOptions{})
+	if err != ErrorNoMatch || len(got.ReferencedURLs) != 1 {
+		t.Errorf("DetectWithOptions() = %+v, error = %v, want URL evidence without match", got.ReferencedURLs, err)
+	}
+}